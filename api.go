@@ -1,20 +1,38 @@
 package vgl
 
 import (
+	"time"
+
 	"github.com/go-glx/vgl/arch"
 	"github.com/go-glx/vgl/config"
-	"github.com/go-glx/vgl/internal/gpu/vlk"
+	"github.com/go-glx/vgl/driver"
+	"github.com/go-glx/vgl/internal/capture"
 )
 
 type Render struct {
 	closer *Closer
-	api    *vlk.VLK
+	api    driver.Driver
+	clock  *frameClock
+	err    error // first failed GPU call in frame API, see Err
 }
 
-func NewRender(wm arch.WindowManager, cfg *config.Config) *Render {
+// NewRender create renderer with driver selected in config
+// (vulkan by default, see config.WithDriver).
+// Returned error can be checked with errors.Is (ErrOutOfDeviceMemory,
+// ErrNoSuitableGPU, etc..) or errors.As with *VulkanError
+func NewRender(wm arch.WindowManager, cfg *config.Config) (_ *Render, err error) {
 	closer := newCloser()
-	container := vlk.NewContainer(closer, wm, cfg)
-	renderer := container.VulkanRenderer()
+
+	defer func() {
+		if err != nil {
+			// free already created resources
+			closer.close()
+		}
+	}()
+
+	defer recoverError(&err)
+
+	renderer := newDriver(closer, wm, cfg)
 
 	// init renderer resources and prepare GPU to work
 	renderer.WarmUp()
@@ -23,22 +41,54 @@ func NewRender(wm arch.WindowManager, cfg *config.Config) *Render {
 	return &Render{
 		closer: closer,
 		api:    renderer,
-	}
+		clock:  newFrameClock(time.Now),
+	}, nil
 }
 
 // WaitGPU should be called in graceful engine shutdown
 // before application exit. This command will sleep and wait
 // current io operation done in GPU.
-// SHOULD BE called before Close
+// SHOULD BE called before Close.
+// Unlike other frame calls, it's called after Render.Err too
 func (r *Render) WaitGPU() {
+	var err error
+
+	defer func() {
+		if r.err == nil {
+			r.err = err
+		}
+	}()
+
+	defer recoverError(&err)
+
 	r.api.GPUWait()
 }
 
+// Err return first GPU error of frame API (FrameStart, FrameEnd,
+// Draw*, etc..), usually ErrDeviceLost or ErrOutOfDeviceMemory.
+// After error all frame calls is ignored, and render should be
+// closed (or recreated). Should be checked after FrameEnd:
+//
+//	r.FrameEnd()
+//	if err := r.Err(); err != nil {
+//		// show error to player
+//	}
+func (r *Render) Err() error {
+	return r.err
+}
+
 // Close SHOULD BE called on application exit
 // this will free all vulkan GPU resources, release
 // memory, etc..
 // Render.WaitGPU SHOULD BE called right before Close
-func (r *Render) Close() error {
+func (r *Render) Close() (err error) {
+	defer recoverError(&err)
+
 	r.closer.close()
+
+	if recorder, ok := r.api.(*capture.Recorder); ok {
+		return recorder.Err()
+	}
+
 	return nil
 }
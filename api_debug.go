@@ -0,0 +1,30 @@
+package vgl
+
+// PushDebugGroup open named group of next draw commands.
+// Groups is visible in GPU debuggers (RenderDoc, etc..) when
+// debug is enabled (see config.WithDebug), otherwise ignored.
+// Every group should be closed with PopDebugGroup in same frame:
+//
+//	r.PushDebugGroup("ui")
+//	r.Draw2DRectExt(...)
+//	r.PopDebugGroup()
+func (r *Render) PushDebugGroup(name string) {
+	if r.err != nil {
+		return
+	}
+
+	defer recoverError(&r.err)
+
+	r.api.PushDebugGroup(name)
+}
+
+// PopDebugGroup close latest group, opened with PushDebugGroup
+func (r *Render) PopDebugGroup() {
+	if r.err != nil {
+		return
+	}
+
+	defer recoverError(&r.err)
+
+	r.api.PopDebugGroup()
+}
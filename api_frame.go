@@ -1,35 +1,69 @@
 package vgl
 
-import (
-	"fmt"
-	"time"
-)
-
-// todo: remove debug stats
-var frames uint64 = 0
-
-func init() {
-	timer := time.NewTicker(time.Second)
-	go func() {
-		for {
-			select {
-			case <-timer.C:
-				fmt.Printf("fps: %d\n", frames)
-				frames = 0
-			}
-		}
-	}()
-}
+import "image"
 
 // FrameStart should be called before any drawing in current frame
 func (r *Render) FrameStart() {
+	if r.err != nil {
+		return
+	}
+
+	defer recoverError(&r.err)
+
+	r.clock.start()
 	r.api.FrameStart()
 }
 
 // FrameEnd should be called after any drawing in current frame
 // this function will draw all queued objects in GPU
-// and swap image buffer from GPU to screen
+// and swap image buffer from GPU to screen.
+// Failed GPU calls in frame is reported in Render.Err
 func (r *Render) FrameEnd() {
+	if r.err != nil {
+		return
+	}
+
+	defer recoverError(&r.err)
+
 	r.api.FrameEnd()
-	frames++
+	r.clock.end()
+}
+
+// Stats return counters and timings of latest frame.
+// Stats is updated in every FrameEnd
+func (r *Render) Stats() Stats {
+	return Stats{
+		FPS:        r.clock.fps(),
+		FrameTime:  r.clock.frameTime(),
+		RecordTime: r.clock.recordTime,
+		Frames:     r.clock.frames,
+		Stats:      r.api.Stats(),
+	}
+}
+
+// Screenshot return copy of latest rendered frame.
+// Will return nil, when config.WithFrameReadback is not enabled
+// or no frames rendered yet
+func (r *Render) Screenshot() *image.RGBA {
+	if r.err != nil {
+		return nil
+	}
+
+	defer recoverError(&r.err)
+
+	return r.api.Screenshot()
+}
+
+// SetVSync turn vsync on/off in runtime (for example from
+// game settings menu). Swapchain will be rebuilt with new
+// present mode on next FrameStart. Overrides present modes
+// from config.WithPresentMode and config.WithVSync
+func (r *Render) SetVSync(enabled bool) {
+	if r.err != nil {
+		return
+	}
+
+	defer recoverError(&r.err)
+
+	r.api.SetVSync(enabled)
 }
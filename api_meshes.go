@@ -0,0 +1,62 @@
+package vgl
+
+import (
+	"fmt"
+
+	"github.com/go-glx/vgl/driver"
+	"github.com/go-glx/vgl/glm"
+)
+
+// Mesh is handle of static mesh, see Render.NewMesh
+type Mesh = driver.MeshID
+
+// mesh vertex attributes locations, see Render.NewMesh
+const (
+	MeshLocationPosition = driver.MeshLocationPosition
+	MeshLocationColor    = driver.MeshLocationColor
+)
+
+// NewMesh upload static triangle list into GPU memory once, so
+// it can be drawn every frame without re-uploading. Layout should
+// have position (vec2 or vec3) at MeshLocationPosition and color
+// (vec3, vec4 or rgba8) at MeshLocationColor, other attributes
+// is ignored. Indices is optional (nil will draw vertices in order).
+// Returned error can be checked with errors.Is(err, ErrInvalidMesh)
+func (r *Render) NewMesh(vertices []byte, indices []uint32, layout VertexLayout) (mesh Mesh, err error) {
+	if r.err != nil {
+		return 0, r.err
+	}
+
+	defer recoverError(&err)
+
+	if mesh, err = r.api.NewMesh(vertices, indices, layout); err != nil {
+		return 0, fmt.Errorf("vgl: %w", err)
+	}
+
+	return mesh, nil
+}
+
+// DrawMesh draw mesh, created with NewMesh. Every vertex
+// position is multiplied by transform (A, B, C, D is matrix columns),
+// use glm.Mat4Identity to draw mesh as is
+func (r *Render) DrawMesh(mesh Mesh, transform glm.Mat4) {
+	if r.err != nil {
+		return
+	}
+
+	defer recoverError(&r.err)
+
+	r.api.DrawMesh(mesh, transform)
+}
+
+// FreeMesh release mesh GPU memory, mesh can't be drawn after it.
+// All not freed meshes is released in Render.Close
+func (r *Render) FreeMesh(mesh Mesh) {
+	if r.err != nil {
+		return
+	}
+
+	defer recoverError(&r.err)
+
+	r.api.FreeMesh(mesh)
+}
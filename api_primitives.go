@@ -17,6 +17,12 @@ func (r *Render) Draw2DRectExt(
 	vertexColor [4]glm.Vec3,
 	outline bool,
 ) {
+	if r.err != nil {
+		return
+	}
+
+	defer recoverError(&r.err)
+
 	// todo: outline
 	r.api.DrawRect(vertexPos, vertexColor)
 }
@@ -0,0 +1,123 @@
+package vgl
+
+import (
+	"fmt"
+
+	"github.com/go-glx/vgl/driver"
+	"github.com/go-glx/vgl/internal/gpu/vlk"
+)
+
+type (
+	// ShaderDesc describe custom SPIR-V shader, see Render.RegisterShader
+	ShaderDesc      = driver.ShaderDesc
+	VertexLayout    = driver.VertexLayout
+	VertexAttribute = driver.VertexAttribute
+	Topology        = driver.Topology
+	VertexFormat    = driver.VertexFormat
+)
+
+const (
+	TopologyTriangleList  = driver.TopologyTriangleList
+	TopologyTriangleStrip = driver.TopologyTriangleStrip
+	TopologyLineList      = driver.TopologyLineList
+	TopologyLineStrip     = driver.TopologyLineStrip
+	TopologyPointList     = driver.TopologyPointList
+)
+
+const (
+	VertexFormatFloat = driver.VertexFormatFloat
+	VertexFormatVec2  = driver.VertexFormatVec2
+	VertexFormatVec3  = driver.VertexFormatVec3
+	VertexFormatVec4  = driver.VertexFormatVec4
+	VertexFormatRGBA8 = driver.VertexFormatRGBA8
+)
+
+// RegisterShader compile custom shader, registered shader
+// can be drawn with DrawCustom by its ID. Pipeline for shader
+// is created on first draw. Fragment shader can declare
+//
+//	layout(constant_id = 0) const int outputTransform = 0;
+//
+// to receive swapchain output transform (0 = none, 1 = sRGB, 2 = PQ),
+// same as built-in shaders. Returned error can be checked with
+// errors.Is (ErrInvalidShader, ErrShaderAlreadyRegistered, etc..)
+func (r *Render) RegisterShader(desc ShaderDesc) (err error) {
+	if r.err != nil {
+		return r.err
+	}
+
+	defer recoverError(&err)
+
+	if err = r.api.RegisterShader(desc); err != nil {
+		return fmt.Errorf("vgl: %w", err)
+	}
+
+	return nil
+}
+
+// DrawCustom draw vertices with custom shader, registered in
+// RegisterShader. Vertices is raw data in shader VertexLayout,
+// indices is optional (nil will draw vertices in order).
+// Uniforms is push constants data (ShaderDesc.Uniforms bytes), followed
+// by data of every shader uniform block, ordered by set and binding
+// (see EncodeUniformBlock). Blocks is copied, so uniforms can be
+// reused right after call. Unknown shader or data, that not match
+// it, stop rendering with ErrShaderNotFound or ErrInvalidDraw in Err
+func (r *Render) DrawCustom(shaderID string, vertices []byte, indices []uint32, uniforms []byte) {
+	if r.err != nil {
+		return
+	}
+
+	defer recoverError(&r.err)
+
+	r.api.DrawCustom(shaderID, vertices, indices, uniforms)
+}
+
+// EncodeUniformBlock encode Go struct into std140 layout of uniform
+// block, for DrawCustom uniforms. Struct fields should be exported and
+// declared in same order and types, as block members in shader
+// (float32, int32, uint32, bool, glm.Vec2, glm.Vec3, glm.Vec4,
+// glm.Mat4, arrays and structs of them):
+//
+//	layout(set = 0, binding = 0) uniform Camera { mat4 proj; vec2 offset; float time; };
+//	type Camera struct { Proj glm.Mat4; Offset glm.Vec2; Time float32 }
+func EncodeUniformBlock(value any) ([]byte, error) {
+	data, err := vlk.EncodeUniformBlock(value)
+	if err != nil {
+		return nil, fmt.Errorf("vgl: %w", err)
+	}
+
+	return data, nil
+}
+
+// Uniform is typed encoder of uniform block T, for DrawCustom
+// uniforms. Std140 layout of T is resolved once in NewUniform,
+// so it's cheaper than EncodeUniformBlock for data updated every draw.
+// T has same rules, as in EncodeUniformBlock
+type Uniform[T any] struct {
+	block *vlk.UniformBlock[T]
+}
+
+func NewUniform[T any]() (*Uniform[T], error) {
+	block, err := vlk.NewUniformBlock[T]()
+	if err != nil {
+		return nil, fmt.Errorf("vgl: %w", err)
+	}
+
+	return &Uniform[T]{block: block}, nil
+}
+
+// Size of uniform block in bytes
+func (u *Uniform[T]) Size() int {
+	return u.block.Size()
+}
+
+// Append encode value after uniforms, and return extended slice.
+// Blocks should be appended in same order, as in shader (by set
+// and binding), after push constants data:
+//
+//	uniforms = camera.Append(uniforms[:0], cam)
+//	r.DrawCustom("sprite", vertices, nil, uniforms)
+func (u *Uniform[T]) Append(uniforms []byte, value T) []byte {
+	return u.block.Append(uniforms, value)
+}
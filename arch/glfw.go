@@ -20,16 +20,43 @@ func NewGLFW(
 	fullscreen bool,
 	width int,
 	height int,
-) *GLFW {
+) (*GLFW, error) {
+	return newGLFW(appName, engineName, fullscreen, true, width, height)
+}
+
+// NewHeadlessGLFW will create hidden (not visible) window.
+// Useful for automated tests and CI, where nothing should
+// be displayed on screen (for example: xvfb + lavapipe)
+func NewHeadlessGLFW(
+	appName string,
+	engineName string,
+	width int,
+	height int,
+) (*GLFW, error) {
+	return newGLFW(appName, engineName, false, false, width, height)
+}
+
+func newGLFW(
+	appName string,
+	engineName string,
+	fullscreen bool,
+	visible bool,
+	width int,
+	height int,
+) (*GLFW, error) {
 	// init
 	err := glfw.Init()
 	if err != nil {
-		panic(fmt.Errorf("failed init glfw library: %w", err))
+		return nil, fmt.Errorf("failed init glfw library: %w", err)
 	}
 
 	glfw.WindowHint(glfw.ClientAPI, glfw.NoAPI)
 	glfw.WindowHint(glfw.Resizable, glfw.False)
 
+	if !visible {
+		glfw.WindowHint(glfw.Visible, glfw.False)
+	}
+
 	// create window
 	var monitor *glfw.Monitor
 	if fullscreen {
@@ -39,12 +66,13 @@ func NewGLFW(
 	//
 	window, err := glfw.CreateWindow(width, height, appName, monitor, nil)
 	if err != nil {
-		panic(fmt.Errorf("failed create glfw window: %w", err))
+		glfw.Terminate()
+		return nil, fmt.Errorf("failed create glfw window: %w", err)
 	}
 
 	return &GLFW{
 		custom: NewCustomGLFW(appName, engineName, window),
-	}
+	}, nil
 }
 
 func (g *GLFW) AppName() string {
@@ -0,0 +1,178 @@
+// vglinfo print vulkan capabilities of all GPU's in system, with
+// reasons why GPU can't be used for rendering. Hidden window is
+// used, nothing is displayed on screen.
+//
+//	go run github.com/go-glx/vgl/cmd/vglinfo
+//	go run github.com/go-glx/vgl/cmd/vglinfo --json > vglinfo.json
+package main
+
+import (
+	"encoding/json"
+	"flag"
+	"fmt"
+	"io"
+	"log/slog"
+	"os"
+	"strings"
+	"text/tabwriter"
+
+	"github.com/go-glx/vgl"
+	"github.com/go-glx/vgl/arch"
+	"github.com/go-glx/vgl/config"
+)
+
+func main() {
+	asJSON := flag.Bool("json", false, "print report as JSON")
+	verbose := flag.Bool("v", false, "write debug logs into stderr")
+	flag.Parse()
+
+	if err := run(os.Stdout, *asJSON, *verbose); err != nil {
+		fmt.Fprintf(os.Stderr, "vglinfo: %v\n", err)
+		os.Exit(1)
+	}
+}
+
+func run(out io.Writer, asJSON bool, verbose bool) error {
+	logLevel := slog.LevelWarn
+	if verbose {
+		logLevel = slog.LevelDebug
+	}
+
+	wm, err := arch.NewHeadlessGLFW("vglinfo", "vgl", 320, 240)
+	if err != nil {
+		return fmt.Errorf("failed create hidden window: %w", err)
+	}
+
+	defer wm.Close()
+
+	report, err := vgl.Capabilities(wm, config.NewConfig(
+		config.WithLogger(slog.New(slog.NewTextHandler(os.Stderr, &slog.HandlerOptions{Level: logLevel}))),
+		config.WithLogLevel(logLevel),
+		config.WithHDR(true), // expose HDR surface formats
+	))
+	if err != nil {
+		return err
+	}
+
+	if asJSON {
+		enc := json.NewEncoder(out)
+		enc.SetIndent("", "  ")
+		return enc.Encode(report)
+	}
+
+	printTable(out, report)
+	return nil
+}
+
+func printTable(out io.Writer, report *vgl.CapabilityReport) {
+	fmt.Fprintf(out, "instance extensions: %d available, window require: %s\n\n",
+		len(report.Instance.Extensions),
+		strings.Join(report.Instance.WindowExtensions, ", "),
+	)
+
+	tw := tabwriter.NewWriter(out, 0, 0, 2, ' ', 0)
+	fmt.Fprintln(tw, "#\tGPU\tTYPE\tVENDOR\tDRIVER\tAPI\tVRAM\tSUITABLE\tSCORE\tSELECTED")
+
+	for _, gpu := range report.GPUs {
+		fmt.Fprintf(tw, "%d\t%s\t%s\t%s\t%s\t%s\t%s\t%s\t%d\t%s\n",
+			gpu.Index,
+			gpu.Name,
+			gpu.Type,
+			gpu.Vendor,
+			gpu.DriverVersion,
+			gpu.APIVersion,
+			formatSize(gpu.DeviceLocalMemory()),
+			yesNo(gpu.Suitable),
+			gpu.Score,
+			mark(gpu.Selected),
+		)
+	}
+
+	_ = tw.Flush()
+
+	for _, gpu := range report.GPUs {
+		printGPU(out, gpu)
+	}
+}
+
+func printGPU(out io.Writer, gpu vgl.GPUReport) {
+	fmt.Fprintf(out, "\nGPU %d: %s\n", gpu.Index, gpu.Name)
+
+	tw := tabwriter.NewWriter(out, 0, 0, 1, ' ', 0)
+	row := func(name string, value string) {
+		fmt.Fprintf(tw, "  %s:\t%s\n", name, value)
+	}
+
+	problems := "-"
+	if len(gpu.Problems) > 0 {
+		problems = strings.Join(gpu.Problems, "; ")
+	}
+
+	formats := make([]string, 0, len(gpu.SurfaceFormats))
+	for _, format := range gpu.SurfaceFormats {
+		formats = append(formats, format.Format+"/"+format.ColorSpace)
+	}
+
+	heaps := make([]string, 0, len(gpu.MemoryHeaps))
+	for _, heap := range gpu.MemoryHeaps {
+		kind := "host"
+		if heap.DeviceLocal {
+			kind = "device"
+		}
+
+		heaps = append(heaps, fmt.Sprintf("%s (%s)", formatSize(heap.Size), kind))
+	}
+
+	maxImages := "unlimited"
+	if gpu.MaxImageCount > 0 {
+		maxImages = fmt.Sprintf("%d", gpu.MaxImageCount)
+	}
+
+	row("problems", problems)
+	row("families", fmt.Sprintf("graphics=%s (%d), present=%s (%d)",
+		yesNo(gpu.Families.Graphics), gpu.Families.GraphicsFamilyID,
+		yesNo(gpu.Families.Present), gpu.Families.PresentFamilyID,
+	))
+	row("surface formats", strings.Join(formats, ", "))
+	row("present modes", strings.Join(gpu.PresentModes, ", "))
+	row("swapchain images", fmt.Sprintf("%d..%s", gpu.MinImageCount, maxImages))
+	row("memory heaps", strings.Join(heaps, ", "))
+	row("features", strings.Join(gpu.Features, ", "))
+	row("limits", fmt.Sprintf("image2D=%d, pushConstants=%d, descriptorSets=%d, allocations=%d, viewports=%d",
+		gpu.Limits.MaxImageDimension2D,
+		gpu.Limits.MaxPushConstantsSize,
+		gpu.Limits.MaxBoundDescriptorSets,
+		gpu.Limits.MaxMemoryAllocations,
+		gpu.Limits.MaxViewports,
+	))
+	row("extensions", fmt.Sprintf("%d (see --json for full list)", len(gpu.Extensions)))
+
+	_ = tw.Flush()
+}
+
+func formatSize(bytes uint64) string {
+	const gb = 1024 * 1024 * 1024
+	const mb = 1024 * 1024
+
+	if bytes >= gb {
+		return fmt.Sprintf("%.1f GB", float64(bytes)/gb)
+	}
+
+	return fmt.Sprintf("%d MB", bytes/mb)
+}
+
+func yesNo(v bool) string {
+	if v {
+		return "yes"
+	}
+
+	return "no"
+}
+
+func mark(v bool) string {
+	if v {
+		return "*"
+	}
+
+	return ""
+}
@@ -63,9 +63,16 @@ func TestCompile(t *testing.T) {
 				config.WithDebug(true),
 			)
 
-			wm := arch.NewGLFW("test", "test", false, 320, 240)
+			wm, err := arch.NewGLFW("test", "test", false, 320, 240)
+			if err != nil {
+				t.Fatalf("failed create window: %v", err)
+			}
+
+			renderer, err := NewRender(wm, cfg)
+			if err != nil {
+				t.Fatalf("failed create render: %v", err)
+			}
 
-			renderer := NewRender(wm, cfg)
 			_ = renderer.Close()
 		})
 	}
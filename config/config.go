@@ -1,23 +1,65 @@
 package config
 
+import (
+	"io"
+	"log/slog"
+
+	"github.com/go-glx/vgl/driver"
+)
+
 type (
 	Config struct {
-		debug bool
-		gpu   configGpu
+		debug        bool
+		onValidation func(err error)
+		driver       DriverType
+		customDriver driver.Driver
+		recording    io.Writer
+		logger       *slog.Logger
+		logLevel     *slog.Level // nil is info, or debug in debug mode
+		shadersDir   string
+		gpu          configGpu
 	}
 
 	configGpu struct {
-		vSync bool
+		selector       GPUSelector
+		vSync          bool
+		presentModes   []PresentMode
+		readback       bool
+		hdr            bool
+		framesInFlight int
 	}
 
 	Configure = func(*Config)
+
+	DriverType uint8
+)
+
+const (
+	// DriverVulkan is default GPU driver
+	DriverVulkan DriverType = iota
+
+	// DriverSoftware is CPU rasterizer. Its slow, but
+	// deterministic and not require any GPU/vulkan
+	DriverSoftware
 )
 
 func NewConfig(opts ...Configure) *Config {
 	cfg := &Config{
-		debug: false,
+		debug:        false,
+		onValidation: nil,
+		driver:       DriverVulkan,
+		customDriver: nil,
+		recording:    nil,
+		logger:       slog.Default(),
+		logLevel:     nil,
+		shadersDir:   "",
 		gpu: configGpu{
-			vSync: false,
+			selector:       AnyGPU(),
+			vSync:          false,
+			presentModes:   nil,
+			readback:       false,
+			hdr:            false,
+			framesInFlight: 2,
 		},
 	}
 
@@ -28,19 +70,151 @@ func NewConfig(opts ...Configure) *Config {
 	return cfg
 }
 
-// WithDebug will print vulkan validation errors
-// on stdout. Its require vulkan SDK to work
+// WithDebug will enable vulkan validation layers and write
+// validation messages into logger. Its require vulkan SDK to work.
+// Debug mode also lower log level to debug, unless it
+// set explicitly with WithLogLevel
 func WithDebug(enabled bool) Configure {
 	return func(config *Config) {
 		config.debug = enabled
 	}
 }
 
+// WithLogger will write all library logs into specified logger.
+// By default slog.Default is used
+func WithLogger(logger *slog.Logger) Configure {
+	return func(config *Config) {
+		config.logger = logger
+	}
+}
+
+// WithLogLevel set minimum level of library logs (default is
+// info, or debug with WithDebug). slog.LevelDebug will log all
+// created/freed GPU resources, available extensions, GPU scoring, etc..
+func WithLogLevel(level slog.Level) Configure {
+	return func(config *Config) {
+		config.logLevel = &level
+	}
+}
+
+// WithValidationErrors set handler for vulkan validation errors.
+// Works only in debug mode (see WithDebug) with vulkan SDK installed.
+// Handler is called on render thread after failed frame (or init),
+// so it can safely fail test or panic:
+//
+//	config.WithValidationErrors(func(err error) { t.Error(err) })
+//	config.WithValidationErrors(func(err error) { panic(err) })
+//
+// All validation messages is also written to logger
+func WithValidationErrors(handler func(err error)) Configure {
+	return func(config *Config) {
+		config.onValidation = handler
+	}
+}
+
+// WithDriver select render backend (vulkan, software, etc..)
+func WithDriver(driverType DriverType) Configure {
+	return func(config *Config) {
+		config.driver = driverType
+	}
+}
+
+// WithCustomDriver will use specified driver implementation
+// instead of build-in drivers. Useful for mocks in tests.
+// This option override WithDriver
+func WithCustomDriver(drv driver.Driver) Configure {
+	return func(config *Config) {
+		config.customDriver = drv
+	}
+}
+
+// WithRecording will write every rendered frame with all
+// driver calls into capture stream (JSON lines, one frame per line).
+// Capture can be played again with vgl.Replay, without game running
+func WithRecording(capture io.Writer) Configure {
+	return func(config *Config) {
+		config.recording = capture
+	}
+}
+
+// WithShaderHotReload is development mode, that will watch
+// compiled shaders in dir and reload changed shaders without restart.
+// Shader files is matched by shader ID: "<dir>/<id>.vert.spv"
+// and "<dir>/<id>.frag.spv" (build-in shaders is in vlk/shaders).
+// Only bytecode is reloaded, so shader inputs and uniforms
+// should not be changed. Shader with broken bytecode is not
+// reloaded, and previous version will be used
+func WithShaderHotReload(dir string) Configure {
+	return func(config *Config) {
+		config.shadersDir = dir
+	}
+}
+
+// WithGPU select GPU used for rendering (on machines with
+// many GPU's, like hybrid laptops). By default, GPU with best
+// score is used. VGL_GPU environment variable override it:
+//
+//	config.WithGPU(config.GPUByName("nvidia"))
+//	config.WithGPU(config.GPUByType(config.GPUTypeIntegrated))
+func WithGPU(selector GPUSelector) Configure {
+	return func(config *Config) {
+		config.gpu.selector = selector
+	}
+}
+
 // WithVSync will use FIFO rendering
 // true - vsync, good for mobile (small power consumption)
 // false - low latency, high power consumption
+// This option is ignored, when WithPresentMode is used
 func WithVSync(enabled bool) Configure {
 	return func(config *Config) {
 		config.gpu.vSync = enabled
 	}
 }
+
+// WithPresentMode set ordered list of preferred present modes,
+// first mode supported by GPU will be used. FIFO is always
+// supported, and used when none of preferred modes is available:
+//
+//	config.WithPresentMode(config.PresentModeMailbox, config.PresentModeImmediate)
+//
+// This option override WithVSync
+func WithPresentMode(preferred ...PresentMode) Configure {
+	return func(config *Config) {
+		config.gpu.presentModes = preferred
+	}
+}
+
+// WithFrameReadback will copy every rendered frame from GPU
+// back to CPU memory, so it can be accessed with Render.Screenshot.
+// This is slow, and should be used only for tests/debug
+func WithFrameReadback(enabled bool) Configure {
+	return func(config *Config) {
+		config.gpu.readback = enabled
+	}
+}
+
+// WithHDR will use HDR swapchain (HDR10 or scRGB), when it
+// supported by GPU, driver and display. Otherwise, library
+// will fallback to SDR output. All colors is still specified
+// in SDR range [0..1], and will look same in HDR output.
+// HDR is ignored, when frame readback is enabled
+func WithHDR(enabled bool) Configure {
+	return func(config *Config) {
+		config.gpu.hdr = enabled
+	}
+}
+
+// WithFramesInFlight set how much frames CPU can record,
+// while GPU still rendering previous frames (default is 2).
+// More frames give better GPU utilization, but add input latency.
+// Values less than 1 is ignored
+func WithFramesInFlight(count int) Configure {
+	return func(config *Config) {
+		if count < 1 {
+			return
+		}
+
+		config.gpu.framesInFlight = count
+	}
+}
@@ -0,0 +1,59 @@
+package config
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestConfig_FramesInFlight(t *testing.T) {
+	tests := []struct {
+		name string
+		opts []Configure
+		want int
+	}{
+		{name: "default", opts: nil, want: 2},
+		{name: "triple", opts: []Configure{WithFramesInFlight(3)}, want: 3},
+		{name: "invalid ignored", opts: []Configure{WithFramesInFlight(0)}, want: 2},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := NewConfig(tt.opts...).FramesInFlight(); got != tt.want {
+				t.Errorf("FramesInFlight() = %d, want %d", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestConfig_PresentModes(t *testing.T) {
+	tests := []struct {
+		name string
+		opts []Configure
+		want []PresentMode
+	}{
+		{
+			name: "default without vsync",
+			opts: nil,
+			want: []PresentMode{PresentModeMailbox, PresentModeImmediate, PresentModeFIFO},
+		},
+		{
+			name: "vsync",
+			opts: []Configure{WithVSync(true)},
+			want: []PresentMode{PresentModeFIFO},
+		},
+		{
+			name: "explicit override vsync",
+			opts: []Configure{WithVSync(true), WithPresentMode(PresentModeImmediate, PresentModeFIFORelaxed)},
+			want: []PresentMode{PresentModeImmediate, PresentModeFIFORelaxed},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := NewConfig(tt.opts...).PresentModes()
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("PresentModes() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
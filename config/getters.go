@@ -1,9 +1,87 @@
 package config
 
+import (
+	"io"
+	"log/slog"
+
+	"github.com/go-glx/vgl/driver"
+)
+
 func (c *Config) InDebug() bool {
 	return c.debug
 }
 
+func (c *Config) ValidationErrorHandler() func(err error) {
+	return c.onValidation
+}
+
+// Logger return library logger with configured min log level
+func (c *Config) Logger() *slog.Logger {
+	return newLevelLogger(c.logger, c.LogLevel())
+}
+
+// LogLevel return min level of library logs
+func (c *Config) LogLevel() slog.Level {
+	if c.logLevel != nil {
+		return *c.logLevel
+	}
+
+	if c.debug {
+		return slog.LevelDebug
+	}
+
+	return slog.LevelInfo
+}
+
+func (c *Config) Driver() DriverType {
+	return c.driver
+}
+
+func (c *Config) CustomDriver() driver.Driver {
+	return c.customDriver
+}
+
+func (c *Config) Recording() io.Writer {
+	return c.recording
+}
+
+// ShaderHotReloadDir return dir with watched shaders,
+// or empty string, when hot reload is disabled
+func (c *Config) ShaderHotReloadDir() string {
+	return c.shadersDir
+}
+
+// GPU return GPU selector from VGL_GPU env, or from WithGPU
+func (c *Config) GPU() GPUSelector {
+	if selector, ok := gpuFromEnv(); ok {
+		return selector
+	}
+
+	return c.gpu.selector
+}
+
 func (c *Config) HasGPUVSync() bool {
 	return c.gpu.vSync
 }
+
+// PresentModes return ordered list of preferred present modes,
+// from WithPresentMode, or based on WithVSync when not set
+func (c *Config) PresentModes() []PresentMode {
+	if len(c.gpu.presentModes) > 0 {
+		return c.gpu.presentModes
+	}
+
+	return VSyncPresentModes(c.gpu.vSync)
+}
+
+func (c *Config) HasFrameReadback() bool {
+	return c.gpu.readback
+}
+
+func (c *Config) HasHDR() bool {
+	return c.gpu.hdr
+}
+
+func (c *Config) FramesInFlight() int {
+	return c.gpu.framesInFlight
+}
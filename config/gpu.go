@@ -0,0 +1,112 @@
+package config
+
+import (
+	"os"
+	"strconv"
+	"strings"
+)
+
+// GPUEnv is environment variable, that override GPU selected
+// in config (see WithGPU). Value is parsed with ParseGPUSelector:
+//
+//	VGL_GPU=1           # by index (see vgl.ListGPUs)
+//	VGL_GPU=integrated  # by type
+//	VGL_GPU=nvidia      # by name substring
+const GPUEnv = "VGL_GPU"
+
+type (
+	// GPUSelector override automatic GPU selection, by default
+	// GPU with best score is used (discrete, more VRAM, etc..).
+	// When selector not match any suitable GPU, best GPU is used.
+	// Zero value is same as AnyGPU
+	GPUSelector struct {
+		byIndex bool
+		index   int
+		name    string
+		types   []GPUType
+	}
+
+	GPUType string
+)
+
+const (
+	GPUTypeDiscrete   GPUType = "discrete"
+	GPUTypeIntegrated GPUType = "integrated"
+	GPUTypeVirtual    GPUType = "virtual"
+	GPUTypeCPU        GPUType = "cpu"
+)
+
+// AnyGPU select GPU with best score (default)
+func AnyGPU() GPUSelector {
+	return GPUSelector{}
+}
+
+// GPUByIndex select GPU by enumeration index (see vgl.ListGPUs)
+func GPUByIndex(index int) GPUSelector {
+	return GPUSelector{byIndex: true, index: index}
+}
+
+// GPUByName select first GPU, which name contains
+// substring (case-insensitive), for example "nvidia"
+func GPUByName(substring string) GPUSelector {
+	return GPUSelector{name: substring}
+}
+
+// GPUByType select best GPU of first available type from
+// ordered preference list. For example, integrated GPU on
+// hybrid laptops for lower power consumption:
+//
+//	config.GPUByType(config.GPUTypeIntegrated, config.GPUTypeDiscrete)
+func GPUByType(prefer ...GPUType) GPUSelector {
+	return GPUSelector{types: prefer}
+}
+
+// ParseGPUSelector parse selector from string: number is
+// index, known GPU type is type, otherwise it's name substring.
+// Empty string is AnyGPU
+func ParseGPUSelector(value string) GPUSelector {
+	value = strings.TrimSpace(value)
+
+	if value == "" {
+		return AnyGPU()
+	}
+
+	if index, err := strconv.Atoi(value); err == nil && index >= 0 {
+		return GPUByIndex(index)
+	}
+
+	switch gpuType := GPUType(strings.ToLower(value)); gpuType {
+	case GPUTypeDiscrete, GPUTypeIntegrated, GPUTypeVirtual, GPUTypeCPU:
+		return GPUByType(gpuType)
+	}
+
+	return GPUByName(value)
+}
+
+// Index return GPU enumeration index, or -1 when not set
+func (s GPUSelector) Index() int {
+	if !s.byIndex {
+		return -1
+	}
+
+	return s.index
+}
+
+// Name return GPU name substring, or empty string when not set
+func (s GPUSelector) Name() string {
+	return s.name
+}
+
+// Types return ordered GPU types preference
+func (s GPUSelector) Types() []GPUType {
+	return s.types
+}
+
+func gpuFromEnv() (GPUSelector, bool) {
+	value, exist := os.LookupEnv(GPUEnv)
+	if !exist || strings.TrimSpace(value) == "" {
+		return GPUSelector{}, false
+	}
+
+	return ParseGPUSelector(value), true
+}
@@ -0,0 +1,57 @@
+package config
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestParseGPUSelector(t *testing.T) {
+	tests := []struct {
+		value string
+		want  GPUSelector
+	}{
+		{value: "", want: AnyGPU()},
+		{value: "1", want: GPUByIndex(1)},
+		{value: "Integrated", want: GPUByType(GPUTypeIntegrated)},
+		{value: "nvidia", want: GPUByName("nvidia")},
+		{value: "-1", want: GPUByName("-1")},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.value, func(t *testing.T) {
+			if got := ParseGPUSelector(tt.value); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("ParseGPUSelector(%q) = %+v, want %+v", tt.value, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestGPUSelector_ZeroValue(t *testing.T) {
+	var zero GPUSelector
+
+	if zero.Index() != -1 || zero.Name() != "" || len(zero.Types()) != 0 {
+		t.Errorf("zero selector should select any GPU, got index=%d name=%q types=%v", zero.Index(), zero.Name(), zero.Types())
+	}
+
+	if !reflect.DeepEqual(zero, AnyGPU()) {
+		t.Errorf("zero selector %+v should be same as AnyGPU %+v", zero, AnyGPU())
+	}
+
+	if index := GPUByIndex(0).Index(); index != 0 {
+		t.Errorf("GPUByIndex(0).Index() = %d, want 0", index)
+	}
+}
+
+func TestConfig_GPU_EnvOverride(t *testing.T) {
+	cfg := NewConfig(WithGPU(GPUByName("nvidia")))
+
+	t.Setenv(GPUEnv, "")
+	if got := cfg.GPU(); !reflect.DeepEqual(got, GPUByName("nvidia")) {
+		t.Errorf("empty env should not override config, got %+v", got)
+	}
+
+	t.Setenv(GPUEnv, "integrated")
+	if got := cfg.GPU(); !reflect.DeepEqual(got, GPUByType(GPUTypeIntegrated)) {
+		t.Errorf("env should override config, got %+v", got)
+	}
+}
@@ -0,0 +1,36 @@
+package config
+
+import (
+	"context"
+	"log/slog"
+)
+
+// levelHandler drop all records below minimum level,
+// even when wrapped handler allow them
+type levelHandler struct {
+	level slog.Leveler
+	inner slog.Handler
+}
+
+func newLevelLogger(logger *slog.Logger, level slog.Leveler) *slog.Logger {
+	return slog.New(&levelHandler{
+		level: level,
+		inner: logger.Handler(),
+	})
+}
+
+func (h *levelHandler) Enabled(ctx context.Context, level slog.Level) bool {
+	return level >= h.level.Level() && h.inner.Enabled(ctx, level)
+}
+
+func (h *levelHandler) Handle(ctx context.Context, record slog.Record) error {
+	return h.inner.Handle(ctx, record)
+}
+
+func (h *levelHandler) WithAttrs(attrs []slog.Attr) slog.Handler {
+	return &levelHandler{level: h.level, inner: h.inner.WithAttrs(attrs)}
+}
+
+func (h *levelHandler) WithGroup(name string) slog.Handler {
+	return &levelHandler{level: h.level, inner: h.inner.WithGroup(name)}
+}
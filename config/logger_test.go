@@ -0,0 +1,53 @@
+package config
+
+import (
+	"bytes"
+	"log/slog"
+	"strings"
+	"testing"
+)
+
+func TestConfig_Logger(t *testing.T) {
+	var out bytes.Buffer
+	handler := slog.NewTextHandler(&out, &slog.HandlerOptions{Level: slog.LevelDebug})
+
+	cfg := NewConfig(
+		WithLogger(slog.New(handler)),
+		WithLogLevel(slog.LevelWarn),
+	)
+
+	logger := cfg.Logger().With(slog.String("module", "test"))
+	logger.Debug("debug message")
+	logger.Info("info message")
+	logger.Warn("warn message")
+
+	logged := out.String()
+	if strings.Contains(logged, "debug message") || strings.Contains(logged, "info message") {
+		t.Fatalf("messages below min level should be dropped, got:\n%s", logged)
+	}
+
+	if !strings.Contains(logged, "warn message") || !strings.Contains(logged, "module=test") {
+		t.Fatalf("warn message with attrs should be logged, got:\n%s", logged)
+	}
+}
+
+func TestConfig_LogLevel(t *testing.T) {
+	tests := []struct {
+		name string
+		opts []Configure
+		want slog.Level
+	}{
+		{name: "default", want: slog.LevelInfo},
+		{name: "debug mode", opts: []Configure{WithDebug(true)}, want: slog.LevelDebug},
+		{name: "explicit level", opts: []Configure{WithLogLevel(slog.LevelWarn)}, want: slog.LevelWarn},
+		{name: "explicit level in debug mode", opts: []Configure{WithLogLevel(slog.LevelWarn), WithDebug(true)}, want: slog.LevelWarn},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := NewConfig(tt.opts...).LogLevel(); got != tt.want {
+				t.Errorf("LogLevel() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,49 @@
+package config
+
+// PresentMode defines how rendered frames is shown on screen
+type PresentMode uint8
+
+const (
+	// PresentModeFIFO is vsync. Frames is queued and shown on
+	// every vertical blank. Low power consumption, but high latency.
+	// Always supported by all GPU's
+	PresentModeFIFO PresentMode = iota
+
+	// PresentModeMailbox is vsync without blocking. GPU render
+	// frames as fast as it can, and only latest is shown on
+	// vertical blank. Low latency, but high power consumption
+	PresentModeMailbox
+
+	// PresentModeImmediate show frames right after render,
+	// without vsync. Lowest latency, but can produce tearing
+	PresentModeImmediate
+
+	// PresentModeFIFORelaxed is same as FIFO, but late frames
+	// is shown immediately (can produce tearing on slow frames)
+	PresentModeFIFORelaxed
+)
+
+func (m PresentMode) String() string {
+	switch m {
+	case PresentModeFIFO:
+		return "fifo"
+	case PresentModeMailbox:
+		return "mailbox"
+	case PresentModeImmediate:
+		return "immediate"
+	case PresentModeFIFORelaxed:
+		return "fifo_relaxed"
+	default:
+		return "unknown"
+	}
+}
+
+// VSyncPresentModes return ordered list of preferred
+// present modes for enabled/disabled vsync
+func VSyncPresentModes(enabled bool) []PresentMode {
+	if enabled {
+		return []PresentMode{PresentModeFIFO}
+	}
+
+	return []PresentMode{PresentModeMailbox, PresentModeImmediate, PresentModeFIFO}
+}
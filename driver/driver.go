@@ -0,0 +1,110 @@
+package driver
+
+import (
+	"image"
+	"time"
+
+	"github.com/go-glx/vgl/glm"
+)
+
+type (
+	// Driver is low level render backend, that used by vgl.Render
+	// for drawing. Available drivers:
+	//  - vulkan   (default, GPU)
+	//  - software (CPU, for tests and machines without vulkan)
+	//
+	// Custom implementations (mocks, recorders, etc..) can be
+	// used with config.WithCustomDriver
+	Driver interface {
+		// WarmUp should create all resources needed for
+		// work. Called one time, before first FrameStart
+		WarmUp()
+
+		// GPUWait should block until all submitted work is done
+		GPUWait()
+
+		// FrameStart called before any drawing in current frame
+		FrameStart()
+
+		// FrameEnd called after all drawing in current frame
+		// driver should submit all queued work and present frame
+		FrameEnd()
+
+		// DrawRect queue rect with per vertex colors
+		DrawRect(vertexPos [4]glm.Vec2, vertexColor [4]glm.Vec3)
+
+		// RegisterShader compile custom shader, that can be
+		// drawn with DrawCustom. Drivers without SPIR-V
+		// support (software) can ignore it
+		RegisterShader(desc ShaderDesc) error
+
+		// DrawCustom queue draw of custom shader. Vertices is raw
+		// vertex buffer (see ShaderDesc.VertexLayout), indices
+		// is optional. Uniforms is per-draw push constants data,
+		// followed by data of shader uniform blocks (by set and binding).
+		// Data that not match shader panics with ErrInvalidDraw
+		DrawCustom(shaderID string, vertices []byte, indices []uint32, uniforms []byte)
+
+		// NewMesh upload static triangle mesh into GPU memory once,
+		// mesh can be drawn many times with DrawMesh. Layout should
+		// have position and color attributes (see ValidateMesh)
+		NewMesh(vertices []byte, indices []uint32, layout VertexLayout) (MeshID, error)
+
+		// DrawMesh queue draw of mesh, every vertex position is
+		// multiplied by transform (A, B, C, D is matrix columns)
+		DrawMesh(mesh MeshID, transform glm.Mat4)
+
+		// FreeMesh release mesh memory, mesh can't be drawn after it
+		FreeMesh(mesh MeshID)
+
+		// PushDebugGroup open named group of next draw commands,
+		// visible in GPU debuggers. Drivers without debug
+		// support can ignore it
+		PushDebugGroup(name string)
+
+		// PopDebugGroup close latest opened debug group
+		PopDebugGroup()
+
+		// SetVSync change present mode (vsync on/off) in runtime,
+		// drivers without presentation can ignore it
+		SetVSync(enabled bool)
+
+		// Screenshot return copy of latest presented frame
+		// or nil, when driver not support it (or it disabled)
+		Screenshot() *image.RGBA
+
+		// Stats return driver counters of latest presented frame
+		Stats() Stats
+	}
+
+	// Stats is driver counters, collected in single frame
+	Stats struct {
+		DrawCalls     uint32 // how many draw commands submitted to GPU
+		Vertices      uint32 // how many vertices processed
+		Batches       uint32 // how many batches (groups of same primitives) flushed
+		PipelineBinds uint32 // how many times graphics pipeline is changed
+
+		// GPU execution time, measured with GPU timestamps (when supported).
+		// GPU timings is collected without stalls, so they always related
+		// to one of previous frames (not latest presented)
+		GPUFrameTime time.Duration
+		GPUGroups    []GPUTiming // time of every debug group (see Driver.PushDebugGroup)
+
+		PresentMode string // used present mode (fifo, mailbox, etc..), empty when not presented on screen
+
+		// GPU memory, allocated by driver at the moment
+		MemoryBlocks      uint32 // device memory allocations (big blocks, shared by resources)
+		MemoryAllocations uint32 // resources placed into blocks
+		MemoryReserved    uint64 // total size of blocks in bytes
+		MemoryUsed        uint64 // bytes used by resources
+
+		// totals from driver start
+		SkippedFrames     uint64 // frames not presented (window minimized, suboptimal swapchain, etc..)
+		SwapchainRebuilds uint64 // how many times swapchain is recreated (window resize, etc..)
+	}
+
+	GPUTiming struct {
+		Name     string
+		Duration time.Duration
+	}
+)
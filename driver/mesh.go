@@ -0,0 +1,124 @@
+package driver
+
+import (
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"math"
+)
+
+// ErrInvalidMesh is returned from NewMesh, when mesh data
+// not match its vertex layout
+var ErrInvalidMesh = errors.New("invalid mesh")
+
+// mesh vertex inputs (layout locations of built-in mesh shader)
+const (
+	MeshLocationPosition = 0 // VertexFormatVec2 or VertexFormatVec3 (z is ignored)
+	MeshLocationColor    = 1 // VertexFormatVec3, VertexFormatVec4 or VertexFormatRGBA8 (alpha is ignored)
+)
+
+// MeshID is handle of static mesh, created with NewMesh.
+// Zero is never used as valid handle
+type MeshID uint32
+
+var (
+	meshPositionFormats = map[VertexFormat]bool{VertexFormatVec2: true, VertexFormatVec3: true}
+	meshColorFormats    = map[VertexFormat]bool{VertexFormatVec3: true, VertexFormatVec4: true, VertexFormatRGBA8: true}
+	vertexFormatSizes   = map[VertexFormat]uint32{
+		VertexFormatFloat: 4,
+		VertexFormatVec2:  8,
+		VertexFormatVec3:  12,
+		VertexFormatVec4:  16,
+		VertexFormatRGBA8: 4,
+	}
+)
+
+// ValidateMesh check that mesh is list of triangles, and
+// layout has position and color attributes (see MeshLocationPosition).
+// Other attributes in layout is allowed and ignored
+func ValidateMesh(vertices []byte, indices []uint32, layout VertexLayout) error {
+	invalid := func(format string, args ...any) error {
+		return fmt.Errorf("%w: %s", ErrInvalidMesh, fmt.Sprintf(format, args...))
+	}
+
+	if layout.Stride == 0 {
+		return invalid("zero vertex stride")
+	}
+
+	if len(vertices) == 0 || len(vertices)%int(layout.Stride) != 0 {
+		return invalid("vertices size %d is not multiple of stride %d", len(vertices), layout.Stride)
+	}
+
+	for _, attr := range layout.Attributes {
+		size, ok := vertexFormatSizes[attr.Format]
+		if !ok {
+			return invalid("attribute %d: unknown format %d", attr.Location, attr.Format)
+		}
+
+		if attr.Offset+size > layout.Stride {
+			return invalid("attribute %d: out of vertex stride %d", attr.Location, layout.Stride)
+		}
+	}
+
+	if attr, ok := layout.attribute(MeshLocationPosition); !ok || !meshPositionFormats[attr.Format] {
+		return invalid("position (location %d) should be vec2 or vec3", MeshLocationPosition)
+	}
+
+	if attr, ok := layout.attribute(MeshLocationColor); !ok || !meshColorFormats[attr.Format] {
+		return invalid("color (location %d) should be vec3, vec4 or rgba8", MeshLocationColor)
+	}
+
+	vertexCount := len(vertices) / int(layout.Stride)
+
+	if len(indices) == 0 {
+		if vertexCount%3 != 0 {
+			return invalid("vertices count %d is not multiple of 3 (triangle list)", vertexCount)
+		}
+
+		return nil
+	}
+
+	if len(indices)%3 != 0 {
+		return invalid("indices count %d is not multiple of 3 (triangle list)", len(indices))
+	}
+
+	for _, index := range indices {
+		if int(index) >= vertexCount {
+			return invalid("index %d is out of %d vertices", index, vertexCount)
+		}
+	}
+
+	return nil
+}
+
+// MeshVertex decode position and color of vertex at index,
+// mesh should be already validated with ValidateMesh
+func MeshVertex(vertices []byte, layout VertexLayout, index int) (x, y float32, r, g, b float32) {
+	vertex := vertices[index*int(layout.Stride):]
+
+	pos, _ := layout.attribute(MeshLocationPosition)
+	x = readFloat(vertex[pos.Offset:])
+	y = readFloat(vertex[pos.Offset+4:])
+
+	color, _ := layout.attribute(MeshLocationColor)
+	if color.Format == VertexFormatRGBA8 {
+		rgba := vertex[color.Offset:]
+		return x, y, float32(rgba[0]) / 255, float32(rgba[1]) / 255, float32(rgba[2]) / 255
+	}
+
+	return x, y, readFloat(vertex[color.Offset:]), readFloat(vertex[color.Offset+4:]), readFloat(vertex[color.Offset+8:])
+}
+
+func (l VertexLayout) attribute(location uint32) (VertexAttribute, bool) {
+	for _, attr := range l.Attributes {
+		if attr.Location == location {
+			return attr, true
+		}
+	}
+
+	return VertexAttribute{}, false
+}
+
+func readFloat(data []byte) float32 {
+	return math.Float32frombits(binary.LittleEndian.Uint32(data))
+}
@@ -0,0 +1,73 @@
+package driver
+
+import "errors"
+
+var (
+	// ErrInvalidDraw is DrawCustom data, that not match shader
+	ErrInvalidDraw = errors.New("invalid draw")
+
+	// ErrShaderAlreadyRegistered is RegisterShader with already used ID
+	ErrShaderAlreadyRegistered = errors.New("shader already registered")
+)
+
+type (
+	// ShaderDesc describe custom shader, compiled into SPIR-V.
+	// Entry point of both stages should be "main"
+	ShaderDesc struct {
+		ID       string // unique shader name, used in DrawCustom
+		Vert     []byte // vertex stage SPIR-V bytecode
+		Frag     []byte // fragment stage SPIR-V bytecode
+		Topology Topology
+
+		// VertexLayout is optional, when empty, layout is reflected
+		// from vertex shader inputs, tightly packed in location order
+		VertexLayout VertexLayout
+
+		// VertexCount is required for shaders without vertex input,
+		// that generate vertices from gl_VertexIndex (fullscreen
+		// triangle, etc..). DrawCustom without indices will draw
+		// this count of vertices. Should be zero for other shaders
+		VertexCount uint32
+
+		// Uniforms is size in bytes of per-draw uniforms block.
+		// Block is passed to both stages as push constants
+		// (layout(push_constant) uniform), vulkan guarantee
+		// at least 128 bytes. Zero will reflect size from shader.
+		// Bigger data can be declared as uniform blocks in descriptor
+		// sets (layout(set, binding) uniform), their size is always
+		// reflected from shader
+		Uniforms uint32
+	}
+
+	// VertexLayout describe one vertex in vertex buffer (binding=0)
+	VertexLayout struct {
+		Stride     uint32 // size of one vertex in bytes
+		Attributes []VertexAttribute
+	}
+
+	VertexAttribute struct {
+		Location uint32 // layout(location = N) in shader
+		Format   VertexFormat
+		Offset   uint32 // offset from vertex start in bytes
+	}
+
+	Topology uint8
+
+	VertexFormat uint8
+)
+
+const (
+	TopologyTriangleList Topology = iota
+	TopologyTriangleStrip
+	TopologyLineList
+	TopologyLineStrip
+	TopologyPointList
+)
+
+const (
+	VertexFormatFloat VertexFormat = iota // float
+	VertexFormatVec2                      // vec2 (2 x float32)
+	VertexFormatVec3                      // vec3 (3 x float32)
+	VertexFormatVec4                      // vec4 (4 x float32)
+	VertexFormatRGBA8                     // vec4 (4 x uint8, normalized to [0..1])
+)
@@ -0,0 +1,47 @@
+package vgl
+
+import (
+	"github.com/go-glx/vgl/arch"
+	"github.com/go-glx/vgl/config"
+	"github.com/go-glx/vgl/driver"
+	"github.com/go-glx/vgl/internal/capture"
+	"github.com/go-glx/vgl/internal/gpu/soft"
+	"github.com/go-glx/vgl/internal/gpu/vlk"
+)
+
+var (
+	_ driver.Driver = (*vlk.VLK)(nil)
+	_ driver.Driver = (*soft.Soft)(nil)
+	_ driver.Driver = (*capture.Recorder)(nil)
+)
+
+func newDriver(closer *Closer, wm arch.WindowManager, cfg *config.Config) driver.Driver {
+	renderer := newBaseDriver(closer, wm, cfg)
+
+	if w := cfg.Recording(); w != nil {
+		return capture.NewRecorder(renderer, w)
+	}
+
+	return renderer
+}
+
+func newBaseDriver(closer *Closer, wm arch.WindowManager, cfg *config.Config) driver.Driver {
+	if custom := cfg.CustomDriver(); custom != nil {
+		return custom
+	}
+
+	switch cfg.Driver() {
+	case config.DriverSoftware:
+		width, height := wm.GetFramebufferSize()
+		renderer := soft.NewSoft(width, height)
+
+		wm.OnWindowResized(func(width, height int) {
+			renderer.Resize(width, height)
+		})
+
+		return renderer
+	default:
+		container := vlk.NewContainer(closer, wm, cfg)
+		return container.VulkanRenderer()
+	}
+}
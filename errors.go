@@ -0,0 +1,67 @@
+package vgl
+
+import (
+	"fmt"
+
+	"github.com/go-glx/vgl/driver"
+	"github.com/go-glx/vgl/internal/gpu/vlk"
+)
+
+// VulkanError is failed vulkan call, it contains
+// vulkan.Result code with its name and description
+type VulkanError = vlk.Error
+
+// errors, that can be checked with errors.Is, for example:
+//
+//	if errors.Is(err, vgl.ErrOutOfDeviceMemory) {
+//		// show "not enough video memory" to player
+//	}
+var (
+	ErrOutOfHostMemory      = vlk.ErrOutOfHostMemory
+	ErrOutOfDeviceMemory    = vlk.ErrOutOfDeviceMemory
+	ErrInitializationFailed = vlk.ErrInitializationFailed
+	ErrDeviceLost           = vlk.ErrDeviceLost
+	ErrLayerNotPresent      = vlk.ErrLayerNotPresent
+	ErrExtensionNotPresent  = vlk.ErrExtensionNotPresent
+	ErrFeatureNotPresent    = vlk.ErrFeatureNotPresent
+	ErrIncompatibleDriver   = vlk.ErrIncompatibleDriver
+	ErrFormatNotSupported   = vlk.ErrFormatNotSupported
+	ErrSurfaceLost          = vlk.ErrSurfaceLost
+	ErrNativeWindowInUse    = vlk.ErrNativeWindowInUse
+	ErrOutOfDate            = vlk.ErrOutOfDate
+	ErrOutOfPoolMemory      = vlk.ErrOutOfPoolMemory
+
+	ErrNoSuitableGPU  = vlk.ErrNoSuitableGPU
+	ErrShaderNotFound = vlk.ErrShaderNotFound
+
+	ErrInvalidShader           = vlk.ErrInvalidShader
+	ErrShaderAlreadyRegistered = vlk.ErrShaderAlreadyRegistered
+
+	// ErrInvalidDraw is DrawCustom data, that not match shader (see Render.Err)
+	ErrInvalidDraw = driver.ErrInvalidDraw
+
+	// ErrInvalidMesh is mesh data, that not match its layout (see Render.NewMesh)
+	ErrInvalidMesh = driver.ErrInvalidMesh
+
+	// ErrValidation is vulkan validation layer error (see config.WithValidationErrors)
+	ErrValidation = vlk.ErrValidation
+)
+
+// recoverError will convert driver panic back to error.
+// Drivers panic with error on any failed GPU call, and
+// this should be deferred in every public function that
+// can fail (frame API use sticky Render.err for it).
+// Not error panics (bugs) is not recovered
+func recoverError(err *error) {
+	recovered := recover()
+	if recovered == nil {
+		return
+	}
+
+	if panicErr, ok := recovered.(error); ok {
+		*err = fmt.Errorf("vgl: %w", panicErr)
+		return
+	}
+
+	panic(recovered)
+}
@@ -0,0 +1,127 @@
+package vgl
+
+import (
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/go-glx/vgl/config"
+	"github.com/go-glx/vgl/driver"
+)
+
+// failedDriver simulate GPU failure on start
+type failedDriver struct {
+	driver.Driver
+	err any
+}
+
+func (d *failedDriver) WarmUp() {
+	panic(d.err)
+}
+
+func TestNewRender_Errors(t *testing.T) {
+	tests := []struct {
+		name string
+		err  error
+	}{
+		{name: "out of device memory", err: ErrOutOfDeviceMemory},
+		{name: "no suitable gpu", err: ErrNoSuitableGPU},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			renderer, err := NewRender(&goldenWM{}, config.NewConfig(
+				config.WithCustomDriver(&failedDriver{err: tt.err}),
+			))
+
+			if renderer != nil {
+				t.Fatalf("render should not be created on error")
+			}
+
+			if !errors.Is(err, tt.err.(error)) {
+				t.Fatalf("NewRender() error = %v, want %v", err, tt.err)
+			}
+		})
+	}
+}
+
+func TestVulkanError(t *testing.T) {
+	var err error = &VulkanError{Result: ErrOutOfDeviceMemory.Result, Where: "alloc.go:10"}
+
+	if !errors.Is(err, ErrOutOfDeviceMemory) {
+		t.Fatalf("error should match by vulkan result")
+	}
+
+	if errors.Is(err, ErrOutOfHostMemory) {
+		t.Fatalf("error should not match different vulkan result")
+	}
+
+	var vkErr *VulkanError
+	if !errors.As(err, &vkErr) || vkErr.Where != "alloc.go:10" {
+		t.Fatalf("error should be unwrapped as *VulkanError")
+	}
+}
+
+func TestNewRender_NotErrorPanic(t *testing.T) {
+	defer func() {
+		if recover() == nil {
+			t.Fatalf("not error panic should not be recovered")
+		}
+	}()
+
+	_, _ = NewRender(&goldenWM{}, config.NewConfig(
+		config.WithCustomDriver(&failedDriver{err: "bug"}),
+	))
+}
+
+// lostDriver simulate device lost in the middle of frame
+type lostDriver struct {
+	driver.Driver
+	frameEnds int
+}
+
+func (d *lostDriver) WarmUp()     {}
+func (d *lostDriver) GPUWait()    {}
+func (d *lostDriver) FrameStart() {}
+
+func (d *lostDriver) FrameEnd() {
+	d.frameEnds++
+	panic(ErrDeviceLost)
+}
+
+func TestRender_Err(t *testing.T) {
+	drv := &lostDriver{}
+
+	renderer, err := NewRender(&goldenWM{}, config.NewConfig(
+		config.WithCustomDriver(drv),
+	))
+	if err != nil {
+		t.Fatalf("failed create render: %v", err)
+	}
+
+	if renderer.Err() != nil {
+		t.Fatalf("render should not have error before frames")
+	}
+
+	for i := 0; i < 2; i++ {
+		renderer.FrameStart()
+		renderer.FrameEnd()
+	}
+
+	if !errors.Is(renderer.Err(), ErrDeviceLost) {
+		t.Fatalf("Err() = %v, want %v", renderer.Err(), ErrDeviceLost)
+	}
+
+	if drv.frameEnds != 1 {
+		t.Fatalf("frame calls after error should be ignored, got %d frame ends", drv.frameEnds)
+	}
+
+	if _, err = renderer.NewMesh(nil, nil, VertexLayout{}); !errors.Is(err, ErrDeviceLost) {
+		t.Fatalf("NewMesh() error = %v, want %v", err, ErrDeviceLost)
+	}
+
+	capture := strings.NewReader("{\"frame\":1,\"calls\":[]}\n")
+	if err = Replay(capture, renderer); !errors.Is(err, ErrDeviceLost) || drv.frameEnds != 1 {
+		t.Fatalf("Replay() error = %v (frame ends %d), want %v without frames", err, drv.frameEnds, ErrDeviceLost)
+	}
+}
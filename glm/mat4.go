@@ -33,6 +33,21 @@ func (v *Mat4) Data() []byte {
 	return (*(*[SizeOfMat4]byte)(unsafe.Pointer(v)))[:]
 }
 
+// Floats return matrix in GLSL mat4 memory layout,
+// where A, B, C, D is columns
+func (v *Mat4) Floats() [16]float32 {
+	var data [16]float32
+
+	for col, vec := range [4]Vec4{v.A, v.B, v.C, v.D} {
+		data[col*4+0] = float32(vec.R)
+		data[col*4+1] = float32(vec.G)
+		data[col*4+2] = float32(vec.B)
+		data[col*4+3] = float32(vec.A)
+	}
+
+	return data
+}
+
 func Mat4Identity() Mat4 {
 	return Mat4{
 		A: Vec4{1, 0, 0, 0},
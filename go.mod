@@ -1,6 +1,6 @@
 module github.com/go-glx/vgl
 
-go 1.18
+go 1.21
 
 require (
 	github.com/go-gl/glfw/v3.3/glfw v0.0.0-20220320163800-277f93cfa958
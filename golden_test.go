@@ -0,0 +1,276 @@
+package vgl
+
+import (
+	"errors"
+	"flag"
+	"fmt"
+	"image"
+	"image/color"
+	"image/png"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/vulkan-go/vulkan"
+
+	"github.com/go-glx/vgl/arch"
+	"github.com/go-glx/vgl/config"
+	"github.com/go-glx/vgl/glm"
+)
+
+// Golden tests render scripted scenes headless with every
+// driver and compare result with PNG images in testdata/golden/<driver>.
+//
+// Update goldens after intended render changes:
+//   go test -run TestGolden -update
+//
+// Scene without golden file is failed. Vulkan driver needs GPU (or
+// mesa lavapipe), so it's run only with -vulkan flag:
+//   xvfb-run go test -run TestGolden -vulkan
+
+var (
+	updateGolden = flag.Bool("update", false, "rewrite golden images in testdata/golden")
+	goldenVulkan = flag.Bool("vulkan", false, "run golden tests with vulkan driver (GPU or lavapipe required)")
+)
+
+const (
+	goldenDir       = "testdata/golden"
+	goldenWidth     = 320
+	goldenHeight    = 240
+	goldenTolerance = 3 // max per channel difference (0..255)
+)
+
+type goldenScene struct {
+	name string
+	draw func(r *Render)
+}
+
+type goldenDriver struct {
+	name   string
+	render func(t *testing.T) *Render
+}
+
+var goldenDrivers = []goldenDriver{
+	{name: "vulkan", render: newHeadlessRender},
+	{name: "software", render: newSoftwareRender},
+}
+
+var goldenScenes = []goldenScene{
+	{
+		name: "empty",
+		draw: func(r *Render) {},
+	},
+	{
+		name: "rect_gradient",
+		draw: func(r *Render) {
+			r.Draw2DRectExt(
+				[4]glm.Vec2{{X: -0.5, Y: -0.5}, {X: 0.5, Y: -0.5}, {X: 0.5, Y: 0.5}, {X: -0.5, Y: 0.5}},
+				[4]glm.Vec3{{R: 1}, {G: 1}, {B: 1}, {R: 1, G: 1, B: 1}},
+				false,
+			)
+		},
+	},
+}
+
+func TestGolden(t *testing.T) {
+	for _, drv := range goldenDrivers {
+		t.Run(drv.name, func(t *testing.T) {
+			renderer := drv.render(t)
+			defer func() {
+				renderer.WaitGPU()
+				_ = renderer.Close()
+			}()
+
+			for _, scene := range goldenScenes {
+				t.Run(scene.name, func(t *testing.T) {
+					renderer.FrameStart()
+					scene.draw(renderer)
+					renderer.FrameEnd()
+
+					assertGolden(t, filepath.Join(drv.name, scene.name), renderer.Screenshot())
+				})
+			}
+		})
+	}
+}
+
+// goldenWM is virtual window with golden frame size
+type goldenWM struct {
+	virtualWM
+}
+
+func (wm *goldenWM) GetFramebufferSize() (width, height int) {
+	return goldenWidth, goldenHeight
+}
+
+func newSoftwareRender(t *testing.T) *Render {
+	t.Helper()
+
+	renderer, err := NewRender(&goldenWM{}, config.NewConfig(
+		config.WithDriver(config.DriverSoftware),
+	))
+	if err != nil {
+		t.Fatalf("failed create software render: %v", err)
+	}
+
+	return renderer
+}
+
+func newHeadlessRender(t *testing.T) *Render {
+	t.Helper()
+
+	if !*goldenVulkan {
+		t.Skip("vulkan goldens is disabled (run with -vulkan)")
+	}
+
+	if err := vulkan.SetDefaultGetInstanceProcAddr(); err != nil {
+		t.Fatalf("vulkan not available: %v", err)
+	}
+
+	wm, err := arch.NewHeadlessGLFW("govgl_test", "govgl_test", goldenWidth, goldenHeight)
+	if err != nil {
+		t.Fatalf("headless window not available: %v", err)
+	}
+
+	// any vulkan API misuse will fail test
+	// (when validation layers is installed)
+	cfg := config.NewConfig(
+		config.WithFrameReadback(true),
+		config.WithDebug(true),
+		config.WithValidationErrors(func(err error) {
+			t.Error(err)
+		}),
+	)
+
+	renderer, err := NewRender(wm, cfg)
+	if err != nil {
+		t.Fatalf("headless render not available: %v", err)
+	}
+
+	return renderer
+}
+
+func assertGolden(t *testing.T, name string, actual *image.RGBA) {
+	t.Helper()
+
+	if actual == nil {
+		t.Fatalf("frame not rendered (nothing to compare)")
+	}
+
+	goldenPath := filepath.Join(goldenDir, name+".png")
+
+	if *updateGolden {
+		if err := writePNG(goldenPath, actual); err != nil {
+			t.Fatalf("failed update golden: %v", err)
+		}
+
+		return
+	}
+
+	expected, err := readPNG(goldenPath)
+	if errors.Is(err, fs.ErrNotExist) {
+		t.Fatalf("golden '%s' not exist (run with -update to create it)", goldenPath)
+	}
+	if err != nil {
+		t.Fatalf("failed read golden: %v", err)
+	}
+
+	diff, mismatched := diffImages(expected, actual, goldenTolerance)
+	if mismatched == 0 {
+		return
+	}
+
+	actualPath := filepath.Join(goldenDir, name+".actual.png")
+	diffPath := filepath.Join(goldenDir, name+".diff.png")
+	_ = writePNG(actualPath, actual)
+	_ = writePNG(diffPath, diff)
+
+	t.Errorf("frame not match golden '%s': %d pixels differ (tolerance=%d), see '%s' and '%s'",
+		goldenPath,
+		mismatched,
+		goldenTolerance,
+		actualPath,
+		diffPath,
+	)
+}
+
+// diffImages return image with all mismatched pixels
+// highlighted in red and count of mismatched pixels
+func diffImages(expected image.Image, actual *image.RGBA, tolerance uint8) (*image.RGBA, int) {
+	bounds := actual.Bounds()
+	diff := image.NewRGBA(bounds)
+
+	if expected.Bounds() != bounds {
+		// all pixels is different
+		for y := bounds.Min.Y; y < bounds.Max.Y; y++ {
+			for x := bounds.Min.X; x < bounds.Max.X; x++ {
+				diff.Set(x, y, color.RGBA{R: 255, A: 255})
+			}
+		}
+
+		return diff, bounds.Dx() * bounds.Dy()
+	}
+
+	mismatched := 0
+	for y := bounds.Min.Y; y < bounds.Max.Y; y++ {
+		for x := bounds.Min.X; x < bounds.Max.X; x++ {
+			want := color.RGBAModel.Convert(expected.At(x, y)).(color.RGBA)
+			got := actual.RGBAAt(x, y)
+
+			if channelDiff(want.R, got.R) > tolerance ||
+				channelDiff(want.G, got.G) > tolerance ||
+				channelDiff(want.B, got.B) > tolerance ||
+				channelDiff(want.A, got.A) > tolerance {
+				mismatched++
+				diff.SetRGBA(x, y, color.RGBA{R: 255, A: 255})
+				continue
+			}
+
+			// matched pixels is dimmed gray
+			gray := uint8((uint16(got.R) + uint16(got.G) + uint16(got.B)) / 3 / 4)
+			diff.SetRGBA(x, y, color.RGBA{R: gray, G: gray, B: gray, A: 255})
+		}
+	}
+
+	return diff, mismatched
+}
+
+func channelDiff(a, b uint8) uint8 {
+	if a > b {
+		return a - b
+	}
+
+	return b - a
+}
+
+func readPNG(path string) (image.Image, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+
+	defer f.Close()
+
+	img, err := png.Decode(f)
+	if err != nil {
+		return nil, fmt.Errorf("failed decode png '%s': %w", path, err)
+	}
+
+	return img, nil
+}
+
+func writePNG(path string, img image.Image) error {
+	if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+		return err
+	}
+
+	f, err := os.Create(path)
+	if err != nil {
+		return err
+	}
+
+	defer f.Close()
+
+	return png.Encode(f, img)
+}
@@ -0,0 +1,51 @@
+package vgl
+
+import (
+	"log/slog"
+
+	"github.com/go-glx/vgl/arch"
+	"github.com/go-glx/vgl/config"
+	"github.com/go-glx/vgl/internal/gpu/vlk"
+)
+
+type (
+	// GPUInfo is description of vulkan GPU
+	GPUInfo = vlk.GPUInfo
+
+	// GPUMemoryHeap is GPU memory heap (VRAM or shared system memory)
+	GPUMemoryHeap = vlk.MemoryHeap
+
+	// GPULimits is some of GPU limits, useful for choosing GPU
+	GPULimits = vlk.GPULimits
+)
+
+// ListGPUs return all vulkan GPU's available in system, in
+// enumeration order. Window is not required. Index of GPU can be
+// used in config.GPUByIndex or in VGL_GPU environment variable.
+// Not every listed GPU can be used for rendering into window
+// (it depends on window surface)
+func ListGPUs() (_ []GPUInfo, err error) {
+	defer recoverError(&err)
+
+	return vlk.ListGPUs(slog.Default()), nil
+}
+
+type (
+	// CapabilityReport describe vulkan loader and all GPU's,
+	// with reasons why GPU can't be used for rendering (see Capabilities)
+	CapabilityReport = vlk.CapabilityReport
+
+	// GPUReport is GPU description for window surface
+	GPUReport = vlk.GPUReport
+)
+
+// Capabilities create vulkan instance and surface for window,
+// and check all GPU's, like it done in NewRender (GPU selector
+// from config is respected). Nothing is rendered, so hidden window
+// can be used (see arch.NewHeadlessGLFW). Report can be attached
+// to bug reports, when game not start on user machine
+func Capabilities(wm arch.WindowManager, cfg *config.Config) (_ *CapabilityReport, err error) {
+	defer recoverError(&err)
+
+	return vlk.NewCapabilityReport(wm, cfg), nil
+}
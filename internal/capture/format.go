@@ -0,0 +1,117 @@
+package capture
+
+import (
+	"github.com/go-glx/vgl/driver"
+	"github.com/go-glx/vgl/glm"
+)
+
+// Capture file is JSON lines stream, where each
+// line is one rendered Frame with all its driver calls:
+//
+//  {"frame":1,"calls":[{"op":"rect","rect":{"pos":[[-1,-1],...],"color":[[1,0,0],...]}}]}
+//  {"frame":2,"calls":[]}
+//
+// Format is text, so captures can be diffed in tests and reviews.
+// Calls made between frames (RegisterShader, SetVSync) is written
+// at start of next frame
+
+type (
+	Op string
+
+	Frame struct {
+		ID    uint64 `json:"frame"`
+		Calls []Call `json:"calls"`
+	}
+
+	Call struct {
+		Op     Op      `json:"op"`
+		Rect   *Rect   `json:"rect,omitempty"`
+		Custom *Custom `json:"custom,omitempty"`
+		Mesh   *Mesh   `json:"mesh,omitempty"`
+		Name   string  `json:"name,omitempty"`
+
+		Shader *driver.ShaderDesc `json:"shader,omitempty"`
+		VSync  *bool              `json:"vsync,omitempty"`
+	}
+
+	Rect struct {
+		Pos   [4][2]float32 `json:"pos"`
+		Color [4][3]float32 `json:"color"`
+	}
+
+	// Custom is draw of custom shader, shader itself is
+	// captured on registration (OpShader) by its ID
+	Custom struct {
+		Shader   string   `json:"shader"`
+		Vertices []byte   `json:"vertices,omitempty"`
+		Indices  []uint32 `json:"indices,omitempty"`
+		Uniforms []byte   `json:"uniforms,omitempty"`
+	}
+
+	// Mesh is static mesh. Mesh data is written once, before
+	// first draw of mesh in capture (OpNewMesh), and next
+	// draws (OpMesh) reference it by recorded ID
+	Mesh struct {
+		ID        driver.MeshID        `json:"id"`
+		Vertices  []byte               `json:"vertices,omitempty"`
+		Indices   []uint32             `json:"indices,omitempty"`
+		Layout    *driver.VertexLayout `json:"layout,omitempty"`
+		Transform *[16]float32         `json:"transform,omitempty"` // column-major
+	}
+)
+
+const (
+	OpRect      Op = "rect"
+	OpCustom    Op = "custom"
+	OpNewMesh   Op = "new_mesh"
+	OpMesh      Op = "mesh"
+	OpPushGroup Op = "push_group"
+	OpPopGroup  Op = "pop_group"
+	OpShader    Op = "shader"
+	OpVSync     Op = "vsync"
+)
+
+func newRect(vertexPos [4]glm.Vec2, vertexColor [4]glm.Vec3) *Rect {
+	rect := &Rect{}
+
+	for i := 0; i < 4; i++ {
+		rect.Pos[i] = [2]float32{vertexPos[i].X, vertexPos[i].Y}
+		rect.Color[i] = [3]float32{vertexColor[i].R, vertexColor[i].G, vertexColor[i].B}
+	}
+
+	return rect
+}
+
+func (r *Rect) Vertexes() ([4]glm.Vec2, [4]glm.Vec3) {
+	var pos [4]glm.Vec2
+	var color [4]glm.Vec3
+
+	for i := 0; i < 4; i++ {
+		pos[i] = glm.Vec2{X: r.Pos[i][0], Y: r.Pos[i][1]}
+		color[i] = glm.Vec3{R: r.Color[i][0], G: r.Color[i][1], B: r.Color[i][2]}
+	}
+
+	return pos, color
+}
+
+func newTransform(m glm.Mat4) *[16]float32 {
+	data := m.Floats()
+	return &data
+}
+
+func (m *Mesh) Mat4() glm.Mat4 {
+	if m.Transform == nil {
+		return glm.Mat4Identity()
+	}
+
+	col := func(ind int) glm.Vec4 {
+		return glm.Vec4{
+			R: float64(m.Transform[ind*4+0]),
+			G: float64(m.Transform[ind*4+1]),
+			B: float64(m.Transform[ind*4+2]),
+			A: float64(m.Transform[ind*4+3]),
+		}
+	}
+
+	return glm.Mat4{A: col(0), B: col(1), C: col(2), D: col(3)}
+}
@@ -0,0 +1,191 @@
+package capture
+
+import (
+	"encoding/json"
+	"fmt"
+	"image"
+	"io"
+
+	"github.com/go-glx/vgl/driver"
+	"github.com/go-glx/vgl/glm"
+)
+
+// Recorder is driver wrapper, that pass all calls to
+// wrapped driver and write every frame into capture stream
+type Recorder struct {
+	inner driver.Driver
+	enc   *json.Encoder
+
+	frame   Frame
+	inFrame bool
+	pending []Call // calls between frames, written in next frame
+	err     error
+
+	meshes   map[driver.MeshID]*Mesh // data of live meshes
+	captured map[driver.MeshID]bool  // mesh data already written
+}
+
+func NewRecorder(inner driver.Driver, w io.Writer) *Recorder {
+	return &Recorder{
+		inner: inner,
+		enc:   json.NewEncoder(w),
+
+		meshes:   make(map[driver.MeshID]*Mesh),
+		captured: make(map[driver.MeshID]bool),
+	}
+}
+
+// Err return first capture write error, if any.
+// After error, recording is stopped, but wrapped
+// driver continue to work
+func (r *Recorder) Err() error {
+	return r.err
+}
+
+func (r *Recorder) WarmUp() {
+	r.inner.WarmUp()
+}
+
+func (r *Recorder) GPUWait() {
+	r.inner.GPUWait()
+}
+
+func (r *Recorder) FrameStart() {
+	r.frame = Frame{
+		ID:    r.frame.ID + 1,
+		Calls: make([]Call, 0, 16+len(r.pending)),
+	}
+	r.inFrame = true
+
+	r.frame.Calls = append(r.frame.Calls, r.pending...)
+	r.pending = r.pending[:0]
+
+	r.inner.FrameStart()
+}
+
+func (r *Recorder) FrameEnd() {
+	r.inner.FrameEnd()
+
+	if !r.inFrame {
+		return
+	}
+
+	r.inFrame = false
+	r.write()
+}
+
+func (r *Recorder) DrawRect(vertexPos [4]glm.Vec2, vertexColor [4]glm.Vec3) {
+	r.record(Call{Op: OpRect, Rect: newRect(vertexPos, vertexColor)})
+	r.inner.DrawRect(vertexPos, vertexColor)
+}
+
+func (r *Recorder) RegisterShader(desc driver.ShaderDesc) error {
+	if err := r.inner.RegisterShader(desc); err != nil {
+		return err
+	}
+
+	captured := desc
+	captured.Vert = append([]byte(nil), desc.Vert...)
+	captured.Frag = append([]byte(nil), desc.Frag...)
+	captured.VertexLayout.Attributes = append([]driver.VertexAttribute(nil), desc.VertexLayout.Attributes...)
+
+	r.recordAnytime(Call{Op: OpShader, Shader: &captured})
+	return nil
+}
+
+func (r *Recorder) DrawCustom(shaderID string, vertices []byte, indices []uint32, uniforms []byte) {
+	r.record(Call{Op: OpCustom, Custom: &Custom{
+		Shader:   shaderID,
+		Vertices: append([]byte(nil), vertices...),
+		Indices:  append([]uint32(nil), indices...),
+		Uniforms: append([]byte(nil), uniforms...),
+	}})
+	r.inner.DrawCustom(shaderID, vertices, indices, uniforms)
+}
+
+func (r *Recorder) NewMesh(vertices []byte, indices []uint32, layout driver.VertexLayout) (driver.MeshID, error) {
+	id, err := r.inner.NewMesh(vertices, indices, layout)
+	if err != nil {
+		return id, err
+	}
+
+	r.meshes[id] = &Mesh{
+		ID:       id,
+		Vertices: append([]byte(nil), vertices...),
+		Indices:  append([]uint32(nil), indices...),
+		Layout: &driver.VertexLayout{
+			Stride:     layout.Stride,
+			Attributes: append([]driver.VertexAttribute(nil), layout.Attributes...),
+		},
+	}
+
+	return id, nil
+}
+
+func (r *Recorder) DrawMesh(mesh driver.MeshID, transform glm.Mat4) {
+	if data, ok := r.meshes[mesh]; ok && r.inFrame && !r.captured[mesh] {
+		r.record(Call{Op: OpNewMesh, Mesh: data})
+		r.captured[mesh] = true
+	}
+
+	r.record(Call{Op: OpMesh, Mesh: &Mesh{ID: mesh, Transform: newTransform(transform)}})
+	r.inner.DrawMesh(mesh, transform)
+}
+
+func (r *Recorder) FreeMesh(mesh driver.MeshID) {
+	delete(r.meshes, mesh)
+	delete(r.captured, mesh)
+	r.inner.FreeMesh(mesh)
+}
+
+func (r *Recorder) PushDebugGroup(name string) {
+	r.record(Call{Op: OpPushGroup, Name: name})
+	r.inner.PushDebugGroup(name)
+}
+
+func (r *Recorder) PopDebugGroup() {
+	r.record(Call{Op: OpPopGroup})
+	r.inner.PopDebugGroup()
+}
+
+func (r *Recorder) SetVSync(enabled bool) {
+	r.recordAnytime(Call{Op: OpVSync, VSync: &enabled})
+	r.inner.SetVSync(enabled)
+}
+
+func (r *Recorder) Screenshot() *image.RGBA {
+	return r.inner.Screenshot()
+}
+
+func (r *Recorder) Stats() driver.Stats {
+	return r.inner.Stats()
+}
+
+func (r *Recorder) record(call Call) {
+	if !r.inFrame {
+		return
+	}
+
+	r.frame.Calls = append(r.frame.Calls, call)
+}
+
+// recordAnytime record call in current frame, or
+// in next frame, when called between frames
+func (r *Recorder) recordAnytime(call Call) {
+	if !r.inFrame {
+		r.pending = append(r.pending, call)
+		return
+	}
+
+	r.record(call)
+}
+
+func (r *Recorder) write() {
+	if r.err != nil {
+		return
+	}
+
+	if err := r.enc.Encode(&r.frame); err != nil {
+		r.err = fmt.Errorf("failed write frame %d to capture: %w", r.frame.ID, err)
+	}
+}
@@ -0,0 +1,102 @@
+package capture
+
+import (
+	"bytes"
+	"fmt"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/go-glx/vgl/driver"
+)
+
+// callsDriver is driver, that remember calls used in capture
+// tests. Not implemented methods of embedded nil driver will panic
+type callsDriver struct {
+	driver.Driver
+	shaders map[string]bool
+	calls   []string
+}
+
+func newCallsDriver() *callsDriver {
+	return &callsDriver{shaders: make(map[string]bool)}
+}
+
+func (d *callsDriver) FrameStart() { d.calls = append(d.calls, "frame_start") }
+func (d *callsDriver) FrameEnd()   { d.calls = append(d.calls, "frame_end") }
+
+func (d *callsDriver) RegisterShader(desc driver.ShaderDesc) error {
+	if d.shaders[desc.ID] {
+		return fmt.Errorf("shader '%s': %w", desc.ID, driver.ErrShaderAlreadyRegistered)
+	}
+
+	d.shaders[desc.ID] = true
+	d.calls = append(d.calls, "shader "+desc.ID+" "+string(desc.Vert))
+	return nil
+}
+
+func (d *callsDriver) DrawCustom(shaderID string, _ []byte, _ []uint32, _ []byte) {
+	d.calls = append(d.calls, "custom "+shaderID)
+}
+
+func (d *callsDriver) SetVSync(enabled bool) {
+	d.calls = append(d.calls, fmt.Sprintf("vsync %v", enabled))
+}
+
+func TestRecorder_BetweenFrames(t *testing.T) {
+	var recorded bytes.Buffer
+	rec := NewRecorder(newCallsDriver(), &recorded)
+
+	if err := rec.RegisterShader(driver.ShaderDesc{ID: "sprite", Vert: []byte("v1"), Frag: []byte("f1")}); err != nil {
+		t.Fatalf("failed register shader: %v", err)
+	}
+
+	rec.SetVSync(false)
+
+	rec.FrameStart()
+	rec.DrawCustom("sprite", nil, nil, nil)
+	rec.SetVSync(true)
+	rec.FrameEnd()
+
+	// failed registration is not captured
+	if err := rec.RegisterShader(driver.ShaderDesc{ID: "sprite"}); err == nil {
+		t.Fatalf("expected error for second registration")
+	}
+
+	rec.FrameStart()
+	rec.FrameEnd()
+
+	if rec.Err() != nil {
+		t.Fatalf("failed record: %v", rec.Err())
+	}
+
+	if count := strings.Count(recorded.String(), `"op":"shader"`); count != 1 {
+		t.Fatalf("capture has %d shaders, want 1:\n%s", count, recorded.String())
+	}
+
+	want := []string{
+		"frame_start", "shader sprite v1", "vsync false", "custom sprite", "vsync true", "frame_end",
+		"frame_start", "frame_end",
+	}
+
+	target := newCallsDriver()
+	if err := Replay(bytes.NewReader(recorded.Bytes()), target); err != nil {
+		t.Fatalf("failed replay: %v", err)
+	}
+
+	if !reflect.DeepEqual(target.calls, want) {
+		t.Fatalf("replayed calls %v, want %v", target.calls, want)
+	}
+
+	// shader, already registered by target, is skipped
+	target = newCallsDriver()
+	target.shaders["sprite"] = true
+
+	if err := Replay(bytes.NewReader(recorded.Bytes()), target); err != nil {
+		t.Fatalf("failed replay into target with shader: %v", err)
+	}
+
+	if len(target.calls) != len(want)-1 {
+		t.Fatalf("replayed calls %v, want %v without shader", target.calls, want)
+	}
+}
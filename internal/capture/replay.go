@@ -0,0 +1,138 @@
+package capture
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+
+	"github.com/go-glx/vgl/driver"
+)
+
+// replayer is state of one replay, recorded mesh
+// IDs is mapped to meshes created in target
+type replayer struct {
+	target driver.Driver
+	meshes map[driver.MeshID]driver.MeshID
+}
+
+// Replay will read all frames from capture stream
+// and execute recorded calls on target driver. Meshes
+// created during replay is freed, when replay is done
+func Replay(capture io.Reader, target driver.Driver) error {
+	dec := json.NewDecoder(capture)
+	rp := &replayer{
+		target: target,
+		meshes: make(map[driver.MeshID]driver.MeshID),
+	}
+
+	defer rp.free()
+
+	for {
+		var frame Frame
+		err := dec.Decode(&frame)
+		if errors.Is(err, io.EOF) {
+			return nil
+		}
+
+		if err != nil {
+			return fmt.Errorf("failed read capture frame: %w", err)
+		}
+
+		if err = rp.replayFrame(frame); err != nil {
+			return fmt.Errorf("failed replay frame %d: %w", frame.ID, err)
+		}
+	}
+}
+
+func (rp *replayer) replayFrame(frame Frame) error {
+	rp.target.FrameStart()
+
+	for ind, call := range frame.Calls {
+		if err := rp.replayCall(call); err != nil {
+			rp.target.FrameEnd()
+			return fmt.Errorf("call #%d: %w", ind, err)
+		}
+	}
+
+	rp.target.FrameEnd()
+	return nil
+}
+
+func (rp *replayer) replayCall(call Call) error {
+	target := rp.target
+
+	switch call.Op {
+	case OpRect:
+		if call.Rect == nil {
+			return fmt.Errorf("op '%s' without data", call.Op)
+		}
+
+		target.DrawRect(call.Rect.Vertexes())
+		return nil
+	case OpCustom:
+		if call.Custom == nil {
+			return fmt.Errorf("op '%s' without data", call.Op)
+		}
+
+		target.DrawCustom(call.Custom.Shader, call.Custom.Vertices, call.Custom.Indices, call.Custom.Uniforms)
+		return nil
+	case OpNewMesh:
+		if call.Mesh == nil || call.Mesh.Layout == nil {
+			return fmt.Errorf("op '%s' without data", call.Op)
+		}
+
+		id, err := target.NewMesh(call.Mesh.Vertices, call.Mesh.Indices, *call.Mesh.Layout)
+		if err != nil {
+			return fmt.Errorf("failed create mesh %d: %w", call.Mesh.ID, err)
+		}
+
+		rp.meshes[call.Mesh.ID] = id
+		return nil
+	case OpMesh:
+		if call.Mesh == nil {
+			return fmt.Errorf("op '%s' without data", call.Op)
+		}
+
+		id, ok := rp.meshes[call.Mesh.ID]
+		if !ok {
+			return fmt.Errorf("mesh %d is not created in capture", call.Mesh.ID)
+		}
+
+		target.DrawMesh(id, call.Mesh.Mat4())
+		return nil
+	case OpPushGroup:
+		target.PushDebugGroup(call.Name)
+		return nil
+	case OpPopGroup:
+		target.PopDebugGroup()
+		return nil
+	case OpShader:
+		if call.Shader == nil {
+			return fmt.Errorf("op '%s' without data", call.Op)
+		}
+
+		// shader can be already registered by replay target
+		err := target.RegisterShader(*call.Shader)
+		if err != nil && !errors.Is(err, driver.ErrShaderAlreadyRegistered) {
+			return fmt.Errorf("failed register shader '%s': %w", call.Shader.ID, err)
+		}
+
+		return nil
+	case OpVSync:
+		if call.VSync == nil {
+			return fmt.Errorf("op '%s' without data", call.Op)
+		}
+
+		target.SetVSync(*call.VSync)
+		return nil
+	default:
+		return fmt.Errorf("unknown op '%s'", call.Op)
+	}
+}
+
+func (rp *replayer) free() {
+	for _, id := range rp.meshes {
+		rp.target.FreeMesh(id)
+	}
+}
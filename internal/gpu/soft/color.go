@@ -0,0 +1,37 @@
+package soft
+
+import "math"
+
+// blend write linear color into pixel.
+// Shaders output alpha=1, so with pipeline blend
+// (srcAlpha, oneMinusSrcAlpha) src just replace dst
+func (s *Soft) blend(x, y int, linear [3]float64) {
+	offset := s.back.PixOffset(x, y)
+	pix := s.back.Pix[offset : offset+4 : offset+4]
+
+	pix[0] = encodeSRGB(linear[0])
+	pix[1] = encodeSRGB(linear[1])
+	pix[2] = encodeSRGB(linear[2])
+	pix[3] = 255
+}
+
+// encodeSRGB convert linear color channel (0 .. 1) into
+// 8-bit sRGB value. This is same transform that GPU do
+// when writing into *_SRGB swapchain images
+func encodeSRGB(c float64) uint8 {
+	if c <= 0 {
+		return 0
+	}
+
+	if c >= 1 {
+		return 255
+	}
+
+	if c <= 0.0031308 {
+		c = c * 12.92
+	} else {
+		c = 1.055*math.Pow(c, 1/2.4) - 0.055
+	}
+
+	return uint8(math.Round(c * 255))
+}
@@ -0,0 +1,96 @@
+package soft
+
+import (
+	"github.com/go-glx/vgl/driver"
+	"github.com/go-glx/vgl/glm"
+)
+
+// mesh is decoded mesh vertexes, software driver
+// has no GPU memory, so mesh is just kept in RAM
+type mesh struct {
+	pos     []glm.Vec2
+	colors  []glm.Vec3
+	indices []uint32
+}
+
+func (s *Soft) NewMesh(vertices []byte, indices []uint32, layout driver.VertexLayout) (driver.MeshID, error) {
+	if err := driver.ValidateMesh(vertices, indices, layout); err != nil {
+		return 0, err
+	}
+
+	count := len(vertices) / int(layout.Stride)
+	m := &mesh{
+		pos:     make([]glm.Vec2, 0, count),
+		colors:  make([]glm.Vec3, 0, count),
+		indices: append([]uint32(nil), indices...),
+	}
+
+	for ind := 0; ind < count; ind++ {
+		x, y, r, g, b := driver.MeshVertex(vertices, layout, ind)
+		m.pos = append(m.pos, glm.Vec2{X: x, Y: y})
+		m.colors = append(m.colors, glm.Vec3{R: r, G: g, B: b})
+	}
+
+	if len(m.indices) == 0 {
+		for ind := 0; ind < count; ind++ {
+			m.indices = append(m.indices, uint32(ind))
+		}
+	}
+
+	s.lastMesh++
+	s.meshes[s.lastMesh] = m
+
+	return s.lastMesh, nil
+}
+
+func (s *Soft) DrawMesh(id driver.MeshID, transform glm.Mat4) {
+	if !s.inFrame {
+		return
+	}
+
+	s.flushRects()
+
+	m, ok := s.meshes[id]
+	if !ok {
+		return
+	}
+
+	pos := make([]glm.Vec2, len(m.pos))
+	for ind, p := range m.pos {
+		pos[ind] = transformPoint(transform, p)
+	}
+
+	for ind := 0; ind+2 < len(m.indices); ind += 3 {
+		a, b, c := m.indices[ind], m.indices[ind+1], m.indices[ind+2]
+
+		s.drawTriangle(
+			[3]glm.Vec2{pos[a], pos[b], pos[c]},
+			[3]glm.Vec3{m.colors[a], m.colors[b], m.colors[c]},
+		)
+	}
+
+	s.frameStats.DrawCalls++
+	s.frameStats.Vertices += uint32(len(m.pos))
+	s.frameStats.Batches++
+}
+
+func (s *Soft) FreeMesh(id driver.MeshID) {
+	delete(s.meshes, id)
+}
+
+// transformPoint multiply transform by vec4(p, 0, 1), same as
+// built-in mesh vertex shader, and do perspective divide
+func transformPoint(m glm.Mat4, p glm.Vec2) glm.Vec2 {
+	x, y := float64(p.X), float64(p.Y)
+
+	outX := m.A.R*x + m.B.R*y + m.D.R
+	outY := m.A.G*x + m.B.G*y + m.D.G
+	outW := m.A.A*x + m.B.A*y + m.D.A
+
+	if outW != 0 && outW != 1 {
+		outX /= outW
+		outY /= outW
+	}
+
+	return glm.Vec2{X: float32(outX), Y: float32(outY)}
+}
@@ -0,0 +1,113 @@
+package soft
+
+import (
+	"math"
+
+	"github.com/go-glx/vgl/glm"
+)
+
+type point struct {
+	x, y float64
+}
+
+// toScreen convert vertex from NDC space (-1 .. 1)
+// into pixel space (0 .. width/height). Same as vulkan
+// viewport transform, where {-1, -1} is top-left
+func (s *Soft) toScreen(v glm.Vec2) point {
+	return point{
+		x: (float64(v.X) + 1) * 0.5 * float64(s.width),
+		y: (float64(v.Y) + 1) * 0.5 * float64(s.height),
+	}
+}
+
+// drawTriangle rasterize triangle with barycentric color
+// interpolation. Pixel is covered when its center is inside
+// triangle, shared edges use top-left fill rule, so
+// adjacent triangles never draw same pixel twice
+func (s *Soft) drawTriangle(pos [3]glm.Vec2, colors [3]glm.Vec3) {
+	p0, p1, p2 := s.toScreen(pos[0]), s.toScreen(pos[1]), s.toScreen(pos[2])
+
+	area := edge(p0, p1, p2)
+	if area == 0 {
+		// degenerate
+		return
+	}
+
+	// culling is disabled in pipeline, so make
+	// all triangles same winding for edge functions
+	if area < 0 {
+		p1, p2 = p2, p1
+		colors[1], colors[2] = colors[2], colors[1]
+		area = -area
+	}
+
+	minX := clampInt(int(math.Floor(math.Min(p0.x, math.Min(p1.x, p2.x)))), 0, s.width)
+	maxX := clampInt(int(math.Ceil(math.Max(p0.x, math.Max(p1.x, p2.x)))), 0, s.width)
+	minY := clampInt(int(math.Floor(math.Min(p0.y, math.Min(p1.y, p2.y)))), 0, s.height)
+	maxY := clampInt(int(math.Ceil(math.Max(p0.y, math.Max(p1.y, p2.y)))), 0, s.height)
+
+	bias0 := fillBias(p1, p2)
+	bias1 := fillBias(p2, p0)
+	bias2 := fillBias(p0, p1)
+
+	for y := minY; y < maxY; y++ {
+		for x := minX; x < maxX; x++ {
+			center := point{x: float64(x) + 0.5, y: float64(y) + 0.5}
+
+			w0 := edge(p1, p2, center)
+			w1 := edge(p2, p0, center)
+			w2 := edge(p0, p1, center)
+
+			if !covered(w0, bias0) || !covered(w1, bias1) || !covered(w2, bias2) {
+				continue
+			}
+
+			w0, w1, w2 = w0/area, w1/area, w2/area
+			s.blend(x, y, interpolate(colors, w0, w1, w2))
+		}
+	}
+}
+
+// edge function, positive when p is on the right
+// side of a->b in screen space (y down)
+func edge(a, b, p point) float64 {
+	return (b.x-a.x)*(p.y-a.y) - (b.y-a.y)*(p.x-a.x)
+}
+
+// fillBias return true when edge a->b is top or left edge
+// of clockwise (on screen) triangle. Pixels exactly on
+// top/left edges are covered, on others not
+func fillBias(a, b point) bool {
+	isTop := a.y == b.y && b.x > a.x
+	isLeft := b.y < a.y
+
+	return isTop || isLeft
+}
+
+func covered(w float64, topLeft bool) bool {
+	if w > 0 {
+		return true
+	}
+
+	return w == 0 && topLeft
+}
+
+func interpolate(colors [3]glm.Vec3, w0, w1, w2 float64) [3]float64 {
+	return [3]float64{
+		float64(colors[0].R)*w0 + float64(colors[1].R)*w1 + float64(colors[2].R)*w2,
+		float64(colors[0].G)*w0 + float64(colors[1].G)*w1 + float64(colors[2].G)*w2,
+		float64(colors[0].B)*w0 + float64(colors[1].B)*w1 + float64(colors[2].B)*w2,
+	}
+}
+
+func clampInt(n, min, max int) int {
+	if n < min {
+		return min
+	}
+
+	if n > max {
+		return max
+	}
+
+	return n
+}
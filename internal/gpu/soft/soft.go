@@ -0,0 +1,166 @@
+package soft
+
+import (
+	"errors"
+	"fmt"
+	"image"
+
+	"github.com/go-glx/vgl/driver"
+	"github.com/go-glx/vgl/glm"
+)
+
+// Soft is pure-go CPU rasterizer driver.
+// It implements same draw surface as vulkan driver, but
+// render everything into image.RGBA in memory.
+//
+// Useful for deterministic tests of primitives geometry
+// and as fallback on machines without vulkan support.
+type Soft struct {
+	width  int
+	height int
+
+	// two frame buffers, swapped on FrameEnd
+	back  *image.RGBA
+	front *image.RGBA
+
+	meshes   map[driver.MeshID]*mesh
+	lastMesh driver.MeshID
+
+	inFrame    bool
+	rects      uint32       // rects drawn since last flush (current batch)
+	stats      driver.Stats // latest presented frame
+	frameStats driver.Stats // current frame (in progress)
+}
+
+func NewSoft(width, height int) *Soft {
+	return &Soft{
+		width:  width,
+		height: height,
+
+		back:  image.NewRGBA(image.Rect(0, 0, width, height)),
+		front: nil,
+
+		meshes: make(map[driver.MeshID]*mesh),
+	}
+}
+
+// WarmUp do nothing, CPU always ready
+func (s *Soft) WarmUp() {}
+
+// GPUWait do nothing, all drawing is synchronous
+func (s *Soft) GPUWait() {}
+
+// Resize will change size of frame buffers, starting
+// from next frame
+func (s *Soft) Resize(width, height int) {
+	s.width = width
+	s.height = height
+	s.back = image.NewRGBA(image.Rect(0, 0, width, height))
+}
+
+func (s *Soft) FrameStart() {
+	s.inFrame = true
+	s.rects = 0
+	s.frameStats = driver.Stats{}
+	s.clear()
+}
+
+func (s *Soft) FrameEnd() {
+	if !s.inFrame {
+		return
+	}
+
+	s.flushRects()
+
+	// "present" back buffer, previous front buffer is reused
+	// for next frame (it's recreated only after Resize)
+	s.front, s.back = s.back, s.front
+	if s.back == nil || s.back.Rect != s.front.Rect {
+		s.back = image.NewRGBA(s.front.Rect)
+	}
+
+	s.inFrame = false
+	s.stats = s.frameStats
+}
+
+func (s *Soft) DrawRect(vertexPos [4]glm.Vec2, vertexColor [4]glm.Vec3) {
+	if !s.inFrame {
+		return
+	}
+
+	// same indexes as vulkan rect: 0,1,2 + 2,3,0
+	s.drawTriangle(
+		[3]glm.Vec2{vertexPos[0], vertexPos[1], vertexPos[2]},
+		[3]glm.Vec3{vertexColor[0], vertexColor[1], vertexColor[2]},
+	)
+	s.drawTriangle(
+		[3]glm.Vec2{vertexPos[2], vertexPos[3], vertexPos[0]},
+		[3]glm.Vec3{vertexColor[2], vertexColor[3], vertexColor[0]},
+	)
+
+	// rects is counted as one batch until other
+	// draw or debug group, same as in vulkan driver
+	s.rects++
+}
+
+// flushRects count current batch of rects
+// as one draw call (rects is already drawn)
+func (s *Soft) flushRects() {
+	if s.rects == 0 {
+		return
+	}
+
+	s.frameStats.DrawCalls++
+	s.frameStats.Vertices += s.rects * 4
+	s.frameStats.Batches++
+	s.rects = 0
+}
+
+// RegisterShader is not supported in software driver,
+// SPIR-V can't be executed on CPU
+func (s *Soft) RegisterShader(desc driver.ShaderDesc) error {
+	return fmt.Errorf("shader '%s': custom shaders in software driver: %w", desc.ID, errors.ErrUnsupported)
+}
+
+// DrawCustom is not supported in software driver, shaders
+// can't be registered, so it will draw nothing
+func (s *Soft) DrawCustom(_ string, _ []byte, _ []uint32, _ []byte) {
+	s.flushRects()
+}
+
+// PushDebugGroup is not supported in software driver
+func (s *Soft) PushDebugGroup(_ string) {
+	s.flushRects()
+}
+
+// PopDebugGroup is not supported in software driver
+func (s *Soft) PopDebugGroup() {
+	s.flushRects()
+}
+
+// SetVSync is not supported in software driver
+func (s *Soft) SetVSync(_ bool) {}
+
+// Screenshot return copy of latest presented frame, or nil
+// when no frames rendered yet
+func (s *Soft) Screenshot() *image.RGBA {
+	if s.front == nil {
+		return nil
+	}
+
+	img := image.NewRGBA(s.front.Rect)
+	copy(img.Pix, s.front.Pix)
+
+	return img
+}
+
+func (s *Soft) Stats() driver.Stats {
+	return s.stats
+}
+
+func (s *Soft) clear() {
+	// same as vulkan main render pass clear value {0, 0, 0, 0}
+	for i := range s.back.Pix {
+		s.back.Pix[i] = 0
+	}
+}
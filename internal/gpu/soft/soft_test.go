@@ -0,0 +1,277 @@
+package soft
+
+import (
+	"encoding/binary"
+	"errors"
+	"image"
+	"image/color"
+	"math"
+	"testing"
+
+	"github.com/go-glx/vgl/driver"
+	"github.com/go-glx/vgl/glm"
+)
+
+var (
+	red   = glm.Vec3{R: 1}
+	green = glm.Vec3{G: 1}
+	white = glm.Vec3{R: 1, G: 1, B: 1}
+
+	rgbaNone  = color.RGBA{}
+	rgbaRed   = color.RGBA{R: 255, A: 255}
+	rgbaGreen = color.RGBA{G: 255, A: 255}
+	rgbaWhite = color.RGBA{R: 255, G: 255, B: 255, A: 255}
+)
+
+func rect(x1, y1, x2, y2 float32) [4]glm.Vec2 {
+	return [4]glm.Vec2{{X: x1, Y: y1}, {X: x2, Y: y1}, {X: x2, Y: y2}, {X: x1, Y: y2}}
+}
+
+func solid(c glm.Vec3) [4]glm.Vec3 {
+	return [4]glm.Vec3{c, c, c, c}
+}
+
+func TestSoft_Screenshot(t *testing.T) {
+	drv := NewSoft(4, 4)
+	if drv.Screenshot() != nil {
+		t.Fatalf("screenshot should be nil before first frame")
+	}
+
+	// draw outside of frame is ignored
+	drv.DrawRect(rect(-1, -1, 1, 1), solid(white))
+
+	drv.FrameStart()
+	drv.FrameEnd()
+
+	img := drv.Screenshot()
+	if img == nil {
+		t.Fatalf("screenshot should exist after frame")
+	}
+
+	assertFilled(t, img, img.Rect, rgbaNone)
+
+	// frame buffers is reused, screenshot is copy
+	drv.FrameStart()
+	drv.DrawRect(rect(-1, -1, 1, 1), solid(white))
+	drv.FrameEnd()
+
+	drv.FrameStart()
+	drv.FrameEnd()
+
+	assertFilled(t, img, img.Rect, rgbaNone)
+}
+
+func TestSoft_Batches(t *testing.T) {
+	drv := NewSoft(4, 4)
+
+	drv.FrameStart()
+	drv.DrawRect(rect(-1, -1, 0, 0), solid(white))
+	drv.DrawRect(rect(0, 0, 1, 1), solid(white))
+	drv.PushDebugGroup("group")
+	drv.DrawRect(rect(-1, 0, 0, 1), solid(white))
+	drv.PopDebugGroup()
+	drv.FrameEnd()
+
+	// two rects before group is one batch
+	if stats := drv.Stats(); stats.DrawCalls != 2 || stats.Vertices != 12 || stats.Batches != 2 {
+		t.Errorf("stats %+v, want 2 draw calls, 12 vertices and 2 batches", stats)
+	}
+}
+
+func TestSoft_RegisterShader(t *testing.T) {
+	drv := NewSoft(4, 4)
+
+	if err := drv.RegisterShader(driver.ShaderDesc{ID: "sprite"}); !errors.Is(err, errors.ErrUnsupported) {
+		t.Errorf("RegisterShader() error = %v, want %v", err, errors.ErrUnsupported)
+	}
+}
+
+func TestSoft_DrawRect(t *testing.T) {
+	tests := []struct {
+		name    string
+		pos     [4]glm.Vec2
+		color   [4]glm.Vec3
+		covered image.Rectangle
+		want    color.RGBA
+	}{
+		{
+			name:    "fullscreen",
+			pos:     rect(-1, -1, 1, 1),
+			color:   solid(white),
+			covered: image.Rect(0, 0, 8, 8),
+			want:    rgbaWhite,
+		},
+		{
+			name:    "center",
+			pos:     rect(-0.5, -0.5, 0.5, 0.5),
+			color:   solid(red),
+			covered: image.Rect(2, 2, 6, 6),
+			want:    rgbaRed,
+		},
+		{
+			name:    "top-left quarter",
+			pos:     rect(-1, -1, 0, 0),
+			color:   solid(green),
+			covered: image.Rect(0, 0, 4, 4),
+			want:    rgbaGreen,
+		},
+		{
+			name:    "counter clockwise",
+			pos:     [4]glm.Vec2{{X: -1, Y: -1}, {X: -1, Y: 0}, {X: 0, Y: 0}, {X: 0, Y: -1}},
+			color:   solid(green),
+			covered: image.Rect(0, 0, 4, 4),
+			want:    rgbaGreen,
+		},
+		{
+			name:    "clipped by screen",
+			pos:     rect(0.5, 0.5, 3, 3),
+			color:   solid(red),
+			covered: image.Rect(6, 6, 8, 8),
+			want:    rgbaRed,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			drv := NewSoft(8, 8)
+			drv.FrameStart()
+			drv.DrawRect(tt.pos, tt.color)
+			drv.FrameEnd()
+
+			img := drv.Screenshot()
+			for y := 0; y < 8; y++ {
+				for x := 0; x < 8; x++ {
+					want := rgbaNone
+					if image.Pt(x, y).In(tt.covered) {
+						want = tt.want
+					}
+
+					if got := img.RGBAAt(x, y); got != want {
+						t.Fatalf("pixel [%d,%d] = %v, want %v", x, y, got, want)
+					}
+				}
+			}
+		})
+	}
+}
+
+func TestSoft_SharedEdges(t *testing.T) {
+	drv := NewSoft(8, 8)
+	drv.FrameStart()
+	drv.DrawRect(rect(-1, -1, 0, 1), solid(red))
+	drv.DrawRect(rect(0, -1, 1, 1), solid(green))
+	drv.FrameEnd()
+
+	img := drv.Screenshot()
+	assertFilled(t, img, image.Rect(0, 0, 4, 8), rgbaRed)
+	assertFilled(t, img, image.Rect(4, 0, 8, 8), rgbaGreen)
+}
+
+func TestSoft_ColorInterpolation(t *testing.T) {
+	drv := NewSoft(2, 1)
+	drv.FrameStart()
+	drv.DrawRect(rect(-1, -1, 1, 1), [4]glm.Vec3{
+		{R: 0.0}, {R: 1.0}, {R: 1.0}, {R: 0.0},
+	})
+	drv.FrameEnd()
+
+	img := drv.Screenshot()
+
+	// pixel centers at 0.25 and 0.75 of linear gradient,
+	// encoded into sRGB like *_SRGB swapchain does
+	if got := img.RGBAAt(0, 0).R; got != encodeSRGB(0.25) {
+		t.Errorf("left pixel R = %d, want %d", got, encodeSRGB(0.25))
+	}
+
+	if got := img.RGBAAt(1, 0).R; got != encodeSRGB(0.75) {
+		t.Errorf("right pixel R = %d, want %d", got, encodeSRGB(0.75))
+	}
+}
+
+func TestEncodeSRGB(t *testing.T) {
+	tests := []struct {
+		linear float64
+		want   uint8
+	}{
+		{linear: -1, want: 0},
+		{linear: 0, want: 0},
+		{linear: 0.001, want: 3},
+		{linear: 0.2159, want: 128},
+		{linear: 0.5, want: 188},
+		{linear: 1, want: 255},
+		{linear: 2, want: 255},
+	}
+
+	for _, tt := range tests {
+		if got := encodeSRGB(tt.linear); got != tt.want {
+			t.Errorf("encodeSRGB(%v) = %d, want %d", tt.linear, got, tt.want)
+		}
+	}
+}
+
+func TestSoft_DrawMesh(t *testing.T) {
+	// quad in top-left quarter: vec2 position + rgba8 color
+	layout := driver.VertexLayout{
+		Stride: 12,
+		Attributes: []driver.VertexAttribute{
+			{Location: driver.MeshLocationPosition, Format: driver.VertexFormatVec2, Offset: 0},
+			{Location: driver.MeshLocationColor, Format: driver.VertexFormatRGBA8, Offset: 8},
+		},
+	}
+
+	vertices := make([]byte, 0, 4*12)
+	for _, pos := range rect(-1, -1, 0, 0) {
+		vertices = binary.LittleEndian.AppendUint32(vertices, math.Float32bits(pos.X))
+		vertices = binary.LittleEndian.AppendUint32(vertices, math.Float32bits(pos.Y))
+		vertices = append(vertices, 255, 0, 0, 255)
+	}
+
+	drv := NewSoft(4, 4)
+
+	if _, err := drv.NewMesh(vertices, []uint32{0, 1, 2, 2, 3}, layout); !errors.Is(err, driver.ErrInvalidMesh) {
+		t.Fatalf("mesh with broken triangle list: err = %v, want ErrInvalidMesh", err)
+	}
+
+	mesh, err := drv.NewMesh(vertices, []uint32{0, 1, 2, 2, 3, 0}, layout)
+	if err != nil {
+		t.Fatalf("failed create mesh: %v", err)
+	}
+
+	// same mesh, moved to top-right quarter
+	moved := glm.Mat4Identity()
+	moved.D.R = 1
+
+	drv.FrameStart()
+	drv.DrawMesh(mesh, glm.Mat4Identity())
+	drv.DrawMesh(mesh, moved)
+	drv.FrameEnd()
+
+	img := drv.Screenshot()
+	assertFilled(t, img, image.Rect(0, 0, 4, 2), rgbaRed)
+	assertFilled(t, img, image.Rect(0, 2, 4, 4), rgbaNone)
+
+	if stats := drv.Stats(); stats.DrawCalls != 2 || stats.Vertices != 8 {
+		t.Errorf("stats %+v, want 2 draw calls and 8 vertices", stats)
+	}
+
+	// freed mesh is not drawn
+	drv.FreeMesh(mesh)
+
+	drv.FrameStart()
+	drv.DrawMesh(mesh, glm.Mat4Identity())
+	drv.FrameEnd()
+
+	assertFilled(t, drv.Screenshot(), image.Rect(0, 0, 4, 4), rgbaNone)
+}
+
+func assertFilled(t *testing.T, img *image.RGBA, area image.Rectangle, want color.RGBA) {
+	t.Helper()
+
+	for y := area.Min.Y; y < area.Max.Y; y++ {
+		for x := area.Min.X; x < area.Max.X; x++ {
+			if got := img.RGBAAt(x, y); got != want {
+				t.Fatalf("pixel [%d,%d] = %v, want %v", x, y, got, want)
+			}
+		}
+	}
+}
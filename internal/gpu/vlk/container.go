@@ -1,14 +1,18 @@
 package vlk
 
 import (
-	"log"
+	"log/slog"
 
 	"github.com/go-glx/vgl/arch"
 	"github.com/go-glx/vgl/config"
+	"github.com/go-glx/vgl/internal/gpu/vlk/internal/buffer"
 	"github.com/go-glx/vgl/internal/gpu/vlk/internal/command"
+	"github.com/go-glx/vgl/internal/gpu/vlk/internal/debugutils"
+	"github.com/go-glx/vgl/internal/gpu/vlk/internal/descriptor"
 	"github.com/go-glx/vgl/internal/gpu/vlk/internal/frame"
 	"github.com/go-glx/vgl/internal/gpu/vlk/internal/instance"
 	"github.com/go-glx/vgl/internal/gpu/vlk/internal/logical"
+	"github.com/go-glx/vgl/internal/gpu/vlk/internal/memory"
 	"github.com/go-glx/vgl/internal/gpu/vlk/internal/physical"
 	"github.com/go-glx/vgl/internal/gpu/vlk/internal/pipeline"
 	"github.com/go-glx/vgl/internal/gpu/vlk/internal/renderpass"
@@ -27,21 +31,34 @@ type Container struct {
 	rebuilder *rebuilder
 	wm        arch.WindowManager
 	cfg       *config.Config
+	logger    *slog.Logger
+
+	// preferred present modes, can be changed in runtime
+	// with VLK.SetVSync (require rebuild)
+	presentModes []config.PresentMode
 
 	// static
 	vlkRef             *VLK
 	vlkInstance        *instance.Instance
+	vlkDebugMessenger  *debugutils.Messenger
+	vlkDebugNames      *debugutils.Names
 	vlkSurface         *surface.Surface
 	vlkPhysicalDevice  *physical.Device
 	vlkLogicalDevice   *logical.Device
-	vlkPipelineFactory *pipeline.Factory
 	vlkShaderManager   *shader.Manager
+	vlkMemoryAllocator *memory.Allocator
+	vlkFrameRing       *buffer.Ring
+	vlkDescLayouts     *descriptor.LayoutCache
+	vlkDescPools       *descriptor.Pools
+	vlkTransfer        *command.Transfer
+	vlkMeshes          *meshStore
 
 	// dynamic
-	vlkCommandPool    *command.Pool
-	vlkSwapChain      *swapchain.Chain
-	vlkFrameManager   *frame.Manager
-	vlkRenderPassMain *renderpass.Pass
+	vlkCommandPool     *command.Pool
+	vlkSwapChain       *swapchain.Chain
+	vlkFrameManager    *frame.Manager
+	vlkRenderPassMain  *renderpass.Pass
+	vlkPipelineFactory *pipeline.Factory
 }
 
 func NewContainer(
@@ -54,6 +71,9 @@ func NewContainer(
 		rebuilder: newRebuilder(),
 		wm:        wm,
 		cfg:       cfg,
+		logger:    cfg.Logger().With(slog.String("driver", "vulkan")),
+
+		presentModes: cfg.PresentModes(),
 	}
 
 	wm.OnWindowResized(func(_, _ int) {
@@ -62,7 +82,7 @@ func NewContainer(
 
 	closer.EnqueueBackFree(func() {
 		cont.rebuilder.free()
-		log.Printf("vk: freed: dynamic resources\n")
+		cont.logger.Debug("freed: dynamic resources")
 	})
 
 	return cont
@@ -72,6 +92,8 @@ func (c *Container) rebuild() {
 	c.VulkanRenderer().maintenance(func() {
 		// free all dynamic resources
 		c.rebuilder.free()
+		c.VulkanRenderer().rebuilds++
+		c.VulkanRenderer().boundPipeline = nil
 
 		// after maintenance is end
 		// all of these resources will be automatic
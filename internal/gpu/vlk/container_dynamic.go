@@ -1,8 +1,11 @@
 package vlk
 
 import (
+	"log/slog"
+
 	"github.com/go-glx/vgl/internal/gpu/vlk/internal/command"
 	"github.com/go-glx/vgl/internal/gpu/vlk/internal/frame"
+	"github.com/go-glx/vgl/internal/gpu/vlk/internal/pipeline"
 	"github.com/go-glx/vgl/internal/gpu/vlk/internal/renderpass"
 	"github.com/go-glx/vgl/internal/gpu/vlk/internal/swapchain"
 )
@@ -12,8 +15,11 @@ func (c *Container) commandPool() *command.Pool {
 		func(x *command.Pool) { x.Free() },
 		func() *command.Pool {
 			return command.NewPool(
+				c.logger.With(slog.String("module", "command")),
+				c.debugNames(),
 				c.physicalDevice(),
 				c.logicalDevice(),
+				c.cfg.FramesInFlight(),
 			)
 		},
 	)
@@ -24,11 +30,16 @@ func (c *Container) frameManager() *frame.Manager {
 		func(x *frame.Manager) { x.Free() },
 		func() *frame.Manager {
 			return frame.NewManager(
+				c.logger.With(slog.String("module", "frame")),
+				c.debugNames(),
+				c.physicalDevice(),
 				c.logicalDevice(),
+				c.memoryAllocator(),
 				c.commandPool(),
 				c.swapChain(),
 				c.renderPassMain(),
 				c.rebuild,
+				c.cfg.HasFrameReadback(),
 			)
 		},
 	)
@@ -40,13 +51,16 @@ func (c *Container) swapChain() *swapchain.Chain {
 		func() *swapchain.Chain {
 			wWidth, wHeight := c.wm.GetFramebufferSize()
 			return swapchain.NewChain(
+				c.logger.With(slog.String("module", "swapchain")),
+				c.debugNames(),
 				uint32(wWidth),
 				uint32(wHeight),
 				c.physicalDevice(),
 				c.logicalDevice(),
 				c.surface(),
 				c.renderPassMain(),
-				c.cfg.HasGPUVSync(),
+				toVulkanPresentModes(c.presentModes),
+				c.cfg.HasFrameReadback(),
 			)
 		},
 	)
@@ -57,9 +71,25 @@ func (c *Container) renderPassMain() *renderpass.Pass {
 		func(x *renderpass.Pass) { x.Free() },
 		func() *renderpass.Pass {
 			return renderpass.NewMain(
+				c.logger.With(slog.String("module", "renderpass")),
+				c.debugNames(),
 				c.physicalDevice(),
 				c.logicalDevice(),
 			)
 		},
 	)
 }
+
+func (c *Container) pipelineFactory() *pipeline.Factory {
+	return dynamic(c, &c.vlkPipelineFactory,
+		func(x *pipeline.Factory) { x.Free() },
+		func() *pipeline.Factory {
+			return pipeline.NewFactory(
+				c.debugNames(),
+				c.logicalDevice(),
+				c.swapChain(),
+				c.renderPassMain(),
+			)
+		},
+	)
+}
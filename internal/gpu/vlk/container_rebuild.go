@@ -18,4 +18,6 @@ func (c *rebuilder) free() {
 	for i := len(c.queue) - 1; i >= 0; i-- {
 		c.queue[i]()
 	}
+
+	c.queue = c.queue[:0]
 }
@@ -2,15 +2,21 @@ package vlk
 
 import (
 	"fmt"
-	"log"
+	"log/slog"
 
 	"github.com/vulkan-go/vulkan"
 
+	"github.com/go-glx/vgl/internal/gpu/vlk/internal/buffer"
+	"github.com/go-glx/vgl/internal/gpu/vlk/internal/command"
+	"github.com/go-glx/vgl/internal/gpu/vlk/internal/debugutils"
+	"github.com/go-glx/vgl/internal/gpu/vlk/internal/def"
+	"github.com/go-glx/vgl/internal/gpu/vlk/internal/descriptor"
 	"github.com/go-glx/vgl/internal/gpu/vlk/internal/instance"
 	"github.com/go-glx/vgl/internal/gpu/vlk/internal/logical"
+	"github.com/go-glx/vgl/internal/gpu/vlk/internal/memory"
 	"github.com/go-glx/vgl/internal/gpu/vlk/internal/physical"
-	"github.com/go-glx/vgl/internal/gpu/vlk/internal/pipeline"
 	"github.com/go-glx/vgl/internal/gpu/vlk/internal/shader"
+	"github.com/go-glx/vgl/internal/gpu/vlk/internal/spirv"
 	"github.com/go-glx/vgl/internal/gpu/vlk/internal/surface"
 )
 
@@ -27,26 +33,69 @@ func (c *Container) instance() *instance.Instance {
 				panic(fmt.Errorf("failed init vulkan: %w", err))
 			}
 
-			log.Printf("vk: lib initialized: [%#v]\n", c.cfg)
+			c.logger.Info("vulkan initialized", slog.Bool("debug", c.cfg.InDebug()))
 
 			// create instance
 			return instance.NewInstance(
 				instance.NewCreateOptions(
+					c.logger.With(slog.String("module", "instance")),
 					c.wm.AppName(),
 					c.wm.EngineName(),
 					c.wm.GetRequiredInstanceExtensions(),
 					c.cfg.InDebug(),
+					c.hdrRequested(),
 				),
 			)
 		},
 	)
 }
 
+// hdrRequested return true, when HDR output should be
+// tried. Readback support only 8-bit per channel images
+func (c *Container) hdrRequested() bool {
+	if !c.cfg.HasHDR() {
+		return false
+	}
+
+	if c.cfg.HasFrameReadback() {
+		c.logger.Warn("HDR output is ignored, because frame readback is enabled")
+		return false
+	}
+
+	return true
+}
+
+func (c *Container) debugMessenger() *debugutils.Messenger {
+	return static(c, &c.vlkDebugMessenger,
+		func(x *debugutils.Messenger) { x.Free() },
+		func() *debugutils.Messenger {
+			return debugutils.NewMessenger(
+				c.logger.With(slog.String("module", "validation")),
+				c.instance(),
+			)
+		},
+	)
+}
+
+func (c *Container) debugNames() *debugutils.Names {
+	return static(c, &c.vlkDebugNames,
+		func(x *debugutils.Names) { x.Free() },
+		func() *debugutils.Names {
+			return debugutils.NewNames(
+				c.logger.With(slog.String("module", "debug")),
+				c.instance(),
+				c.logicalDevice(),
+			)
+		},
+	)
+}
+
 func (c *Container) surface() *surface.Surface {
 	return static(c, &c.vlkSurface,
 		func(x *surface.Surface) { x.Free() },
 		func() *surface.Surface {
 			return surface.NewSurface(
+				c.logger.With(slog.String("module", "surface")),
 				c.instance(),
 				c.wm,
 			)
@@ -59,8 +108,10 @@ func (c *Container) physicalDevice() *physical.Device {
 		func(x *physical.Device) {},
 		func() *physical.Device {
 			return physical.NewDevice(
+				c.logger.With(slog.String("module", "physical")),
 				c.instance(),
 				c.surface(),
+				gpuSelector(c.cfg.GPU()),
 			)
 		},
 	)
@@ -71,38 +122,120 @@ func (c *Container) logicalDevice() *logical.Device {
 		func(x *logical.Device) { x.Free() },
 		func() *logical.Device {
 			return logical.NewDevice(
+				c.logger.With(slog.String("module", "logical")),
 				c.physicalDevice(),
 			)
 		},
 	)
 }
 
-func (c *Container) pipelineFactory() *pipeline.Factory {
-	return static(c, &c.vlkPipelineFactory,
-		func(x *pipeline.Factory) { x.Free() },
-		func() *pipeline.Factory {
-			return pipeline.NewFactory(
-				c.logicalDevice(),
-				c.swapChain(),
-				c.renderPassMain(),
-			)
-		},
-	)
-}
-
 func (c *Container) shaderManager() *shader.Manager {
 	return static(c, &c.vlkShaderManager,
 		func(x *shader.Manager) { x.Free() },
 		func() *shader.Manager {
 			mng := shader.NewManager(
+				c.logger.With(slog.String("module", "shader")),
 				c.logicalDevice(),
+				c.debugNames(),
+				spirv.MaxVersion(min(def.VKApiVersion, c.physicalDevice().PrimaryGPU().Props.ApiVersion)),
 			)
 
 			// register build-in shaders
-			mng.RegisterShader(defaultShaderTriangle())
+			for _, meta := range []*shader.Meta{defaultShaderTriangle(), defaultShaderRect(), defaultShaderMesh()} {
+				if err := mng.RegisterShader(meta); err != nil {
+					panic(err)
+				}
+			}
 
 			//
 			return mng
 		},
 	)
 }
+
+func (c *Container) memoryAllocator() *memory.Allocator {
+	return static(c, &c.vlkMemoryAllocator,
+		func(x *memory.Allocator) { x.Free() },
+		func() *memory.Allocator {
+			return memory.NewAllocator(
+				c.logger.With(slog.String("module", "memory")),
+				c.physicalDevice(),
+				c.logicalDevice(),
+				c.cfg.FramesInFlight(),
+			)
+		},
+	)
+}
+
+func (c *Container) frameRing() *buffer.Ring {
+	return static(c, &c.vlkFrameRing,
+		func(x *buffer.Ring) { x.Free() },
+		func() *buffer.Ring {
+			return buffer.NewRing(
+				c.logger.With(slog.String("module", "buffer")),
+				c.debugNames(),
+				c.memoryAllocator(),
+				c.logicalDevice(),
+				"stream",
+				int(c.physicalDevice().PrimaryGPU().Info.Limits.MinUniformBufferAlign),
+				c.cfg.FramesInFlight(),
+			)
+		},
+	)
+}
+
+func (c *Container) descriptorLayouts() *descriptor.LayoutCache {
+	return static(c, &c.vlkDescLayouts,
+		func(x *descriptor.LayoutCache) { x.Free() },
+		func() *descriptor.LayoutCache {
+			return descriptor.NewLayoutCache(
+				c.logger.With(slog.String("module", "descriptor")),
+				c.debugNames(),
+				c.logicalDevice(),
+			)
+		},
+	)
+}
+
+func (c *Container) descriptorPools() *descriptor.Pools {
+	return static(c, &c.vlkDescPools,
+		func(x *descriptor.Pools) { x.Free() },
+		func() *descriptor.Pools {
+			return descriptor.NewPools(
+				c.logger.With(slog.String("module", "descriptor")),
+				c.debugNames(),
+				c.logicalDevice(),
+				c.cfg.FramesInFlight(),
+			)
+		},
+	)
+}
+
+func (c *Container) transferCommands() *command.Transfer {
+	return static(c, &c.vlkTransfer,
+		func(x *command.Transfer) { x.Free() },
+		func() *command.Transfer {
+			return command.NewTransfer(
+				c.logger.With(slog.String("module", "command")),
+				c.physicalDevice(),
+				c.logicalDevice(),
+			)
+		},
+	)
+}
+
+func (c *Container) meshes() *meshStore {
+	return static(c, &c.vlkMeshes,
+		func(x *meshStore) { x.free() },
+		func() *meshStore {
+			return newMeshStore(
+				c.logger.With(slog.String("module", "mesh")),
+				c.debugNames(),
+				c.memoryAllocator(),
+				c.logicalDevice(),
+				c.transferCommands(),
+				c.cfg.FramesInFlight(),
+			)
+		},
+	)
+}
@@ -0,0 +1,358 @@
+package vlk
+
+import (
+	"encoding/binary"
+	"fmt"
+	"slices"
+
+	"github.com/vulkan-go/vulkan"
+
+	"github.com/go-glx/vgl/driver"
+	"github.com/go-glx/vgl/internal/gpu/vlk/internal/buffer"
+	"github.com/go-glx/vgl/internal/gpu/vlk/internal/descriptor"
+	"github.com/go-glx/vgl/internal/gpu/vlk/internal/pipeline"
+	"github.com/go-glx/vgl/internal/gpu/vlk/internal/shader"
+)
+
+var customTopologies = map[driver.Topology]vulkan.PrimitiveTopology{
+	driver.TopologyTriangleList:  vulkan.PrimitiveTopologyTriangleList,
+	driver.TopologyTriangleStrip: vulkan.PrimitiveTopologyTriangleStrip,
+	driver.TopologyLineList:      vulkan.PrimitiveTopologyLineList,
+	driver.TopologyLineStrip:     vulkan.PrimitiveTopologyLineStrip,
+	driver.TopologyPointList:     vulkan.PrimitiveTopologyPointList,
+}
+
+type customVertexFormat struct {
+	format vulkan.Format
+	size   uint32
+}
+
+var customVertexFormats = map[driver.VertexFormat]customVertexFormat{
+	driver.VertexFormatFloat: {format: vulkan.FormatR32Sfloat, size: 4},
+	driver.VertexFormatVec2:  {format: vulkan.FormatR32g32Sfloat, size: 8},
+	driver.VertexFormatVec3:  {format: vulkan.FormatR32g32b32Sfloat, size: 12},
+	driver.VertexFormatVec4:  {format: vulkan.FormatR32g32b32a32Sfloat, size: 16},
+	driver.VertexFormatRGBA8: {format: vulkan.FormatR8g8b8a8Unorm, size: 4},
+}
+
+// customShaderMeta validate user shader description and
+// convert it into vulkan shader meta. Vertex layout and
+// uniforms size is reflected from bytecode, when not set in desc
+func (vlk *VLK) customShaderMeta(desc driver.ShaderDesc) (*shader.Meta, error) {
+	invalid := func(format string, args ...any) error {
+		return fmt.Errorf("shader '%s': %w: %s", desc.ID, shader.ErrInvalidShader, fmt.Sprintf(format, args...))
+	}
+
+	if desc.ID == "" {
+		return nil, invalid("empty id")
+	}
+
+	if vlk.cont.shaderManager().Has(desc.ID) {
+		return nil, fmt.Errorf("shader '%s': %w", desc.ID, shader.ErrShaderAlreadyRegistered)
+	}
+
+	if len(desc.Vert) == 0 || len(desc.Frag) == 0 {
+		return nil, invalid("vertex and fragment bytecode is required")
+	}
+
+	topology, ok := customTopologies[desc.Topology]
+	if !ok {
+		return nil, invalid("unknown topology %d", desc.Topology)
+	}
+
+	meta, err := shader.NewReflectedMeta(desc.ID, desc.Vert, desc.Frag, topology)
+	if err != nil {
+		return nil, err
+	}
+
+	if reflection := meta.Reflection(); reflection != nil {
+		for _, set := range reflection.DescriptorSets {
+			for _, binding := range set.Bindings {
+				// textures and storage buffers can't be bound from public API yet
+				if binding.DescriptorType != vulkan.DescriptorTypeUniformBuffer || binding.DescriptorCount != 1 {
+					return nil, invalid("set=%d binding=%d: only uniform blocks is supported in descriptor sets", set.Set, binding.Binding)
+				}
+			}
+		}
+	}
+
+	if len(desc.VertexLayout.Attributes) > 0 {
+		bindings, attributes, err := customVertexInput(desc.VertexLayout)
+		if err != nil {
+			return nil, invalid("%s", err)
+		}
+
+		for _, reflected := range meta.Attributes() {
+			if !hasLocation(attributes, reflected.Location) {
+				return nil, invalid("shader input location %d is not in vertex layout", reflected.Location)
+			}
+		}
+
+		meta = meta.WithVertexInput(bindings, attributes)
+	}
+
+	if err = checkVertexCount(meta.Bindings(), desc.VertexCount); err != nil {
+		return nil, invalid("%s", err)
+	}
+
+	meta = meta.WithVertexCount(desc.VertexCount)
+
+	if desc.Uniforms > 0 {
+		if reflected := meta.PushConstantsSize(); desc.Uniforms < reflected {
+			return nil, invalid("uniforms size %d is smaller than shader push constants block %d", desc.Uniforms, reflected)
+		}
+
+		meta = meta.WithPushConstantsSize(desc.Uniforms)
+	}
+
+	if meta.PushConstantsSize()%4 != 0 {
+		return nil, invalid("uniforms size %d should be multiple of 4", meta.PushConstantsSize())
+	}
+
+	maxUniforms := vlk.cont.physicalDevice().PrimaryGPU().Info.Limits.MaxPushConstantsSize
+	if meta.PushConstantsSize() > maxUniforms {
+		return nil, invalid("uniforms size %d is above GPU limit %d", meta.PushConstantsSize(), maxUniforms)
+	}
+
+	return meta, nil
+}
+
+func customVertexInput(layout driver.VertexLayout) (
+	[]vulkan.VertexInputBindingDescription,
+	[]vulkan.VertexInputAttributeDescription,
+	error,
+) {
+	attributes := make([]vulkan.VertexInputAttributeDescription, 0, len(layout.Attributes))
+	for _, attr := range layout.Attributes {
+		format, ok := customVertexFormats[attr.Format]
+		if !ok {
+			return nil, nil, fmt.Errorf("attribute %d: unknown format %d", attr.Location, attr.Format)
+		}
+
+		if attr.Offset+format.size > layout.Stride {
+			return nil, nil, fmt.Errorf("attribute %d: out of vertex stride %d", attr.Location, layout.Stride)
+		}
+
+		attributes = append(attributes, vulkan.VertexInputAttributeDescription{
+			Location: attr.Location,
+			Binding:  0,
+			Format:   format.format,
+			Offset:   attr.Offset,
+		})
+	}
+
+	bindings := []vulkan.VertexInputBindingDescription{
+		{
+			Binding:   0,
+			Stride:    layout.Stride,
+			InputRate: vulkan.VertexInputRateVertex,
+		},
+	}
+
+	return bindings, attributes, nil
+}
+
+// EncodeUniformBlock return value in std140 layout of uniform block
+func EncodeUniformBlock(value any) ([]byte, error) {
+	return buffer.EncodeStd140(value)
+}
+
+// UniformBlock is typed std140 encoder of uniform block T
+type UniformBlock[T any] struct {
+	layout *buffer.Std140[T]
+}
+
+func NewUniformBlock[T any]() (*UniformBlock[T], error) {
+	layout, err := buffer.NewStd140[T]()
+	if err != nil {
+		return nil, err
+	}
+
+	return &UniformBlock[T]{layout: layout}, nil
+}
+
+// Size of block in bytes
+func (b *UniformBlock[T]) Size() int {
+	return b.layout.Size()
+}
+
+// Append encode value after dst, and return extended slice
+func (b *UniformBlock[T]) Append(dst []byte, value T) []byte {
+	dst = slices.Grow(dst, b.layout.Size())
+	b.layout.Encode(dst[len(dst):len(dst)+b.layout.Size()], value)
+
+	return dst[:len(dst)+b.layout.Size()]
+}
+
+// customUniformsSize is size of DrawCustom uniforms: push
+// constants block, followed by all uniform blocks of shader
+func customUniformsSize(meta *shader.Meta) uint32 {
+	size := meta.PushConstantsSize()
+
+	if reflection := meta.Reflection(); reflection != nil {
+		for _, block := range reflection.UniformBlocks {
+			size += block.Size
+		}
+	}
+
+	return size
+}
+
+// checkVertexCount validate ShaderDesc.VertexCount, shader without
+// vertex input should know how many vertices to draw
+func checkVertexCount(bindings []vulkan.VertexInputBindingDescription, vertexCount uint32) error {
+	if len(bindings) == 0 && vertexCount == 0 {
+		return fmt.Errorf("shader has no vertex input, VertexCount is required")
+	}
+
+	if len(bindings) > 0 && vertexCount > 0 {
+		return fmt.Errorf("VertexCount is only for shaders without vertex input, vertices count is taken from vertex buffer")
+	}
+
+	return nil
+}
+
+// checkCustomDraw validate DrawCustom data against shader
+func checkCustomDraw(meta *shader.Meta, vertices []byte, indices []uint32, uniforms []byte) error {
+	invalid := func(format string, args ...any) error {
+		return fmt.Errorf("%w: %s", driver.ErrInvalidDraw, fmt.Sprintf(format, args...))
+	}
+
+	if bindings := meta.Bindings(); len(bindings) > 0 && len(vertices)%int(bindings[0].Stride) != 0 {
+		return invalid("vertices size %d is not multiple of stride %d", len(vertices), bindings[0].Stride)
+	}
+
+	vertexCount := customVertexCount(meta, vertices)
+	for ind, index := range indices {
+		if index >= vertexCount {
+			return invalid("index %d at %d is out of %d vertices", index, ind, vertexCount)
+		}
+	}
+
+	if size := customUniformsSize(meta); uint32(len(uniforms)) != size {
+		return invalid("uniforms size %d, expected %d", len(uniforms), size)
+	}
+
+	return nil
+}
+
+// customVertexCount is count of vertices, drawn by not indexed draw
+func customVertexCount(meta *shader.Meta, vertices []byte) uint32 {
+	if len(meta.Bindings()) == 0 {
+		return meta.VertexCount()
+	}
+
+	return uint32(len(vertices)) / meta.Bindings()[0].Stride
+}
+
+func hasLocation(attributes []vulkan.VertexInputAttributeDescription, location uint32) bool {
+	for _, attr := range attributes {
+		if attr.Location == location {
+			return true
+		}
+	}
+
+	return false
+}
+
+// flushCustom record one draw call of custom shader, draw data
+// should be checked with checkCustomDraw, and all not flushed
+// rects should be drawn before it
+func (vlk *VLK) flushCustom(sh *shader.Shader, vertices []byte, indices []uint32, uniforms []byte) {
+	frames := vlk.cont.frameManager()
+	if !frames.Available() {
+		return
+	}
+
+	meta := sh.Meta()
+	pushConstants := uniforms[:meta.PushConstantsSize()]
+	sets := vlk.customDescriptorSets(frames.FrameID(), meta, uniforms[meta.PushConstantsSize():])
+
+	vertexCount := customVertexCount(meta, vertices)
+
+	pipe := vlk.shaderPipeline(sh)
+	layout := vlk.cont.pipelineFactory().Layout(vlk.shaderLayout(meta))
+
+	var vertexes, indexes *buffer.Host
+	var vertexesOffset, indexesOffset int
+
+	if len(vertices) > 0 {
+		vertexes, vertexesOffset = vlk.cont.frameRing().Write(frames.FrameID(), vertices, streamAlignVertex)
+	}
+
+	if len(indices) > 0 {
+		var data []byte
+		indexes, indexesOffset, data = vlk.cont.frameRing().Allocate(frames.FrameID(), len(indices)*4, streamAlignIndex)
+
+		for ind, index := range indices {
+			binary.LittleEndian.PutUint32(data[ind*4:], index)
+		}
+	}
+
+	frames.FrameApplyCommands(func(_ uint32, cb vulkan.CommandBuffer) {
+		vlk.bindPipeline(cb, pipe)
+
+		if len(pushConstants) > 0 {
+			pipeline.PushConstants(cb, layout, meta.PushConstants(), 0, pushConstants)
+		}
+
+		for _, set := range sets {
+			vulkan.CmdBindDescriptorSets(cb, vulkan.PipelineBindPointGraphics, layout, set.index, 1,
+				[]vulkan.DescriptorSet{set.ref},
+				0, nil,
+			)
+		}
+
+		if vertexes != nil {
+			vulkan.CmdBindVertexBuffers(cb, 0, 1,
+				[]vulkan.Buffer{vertexes.Ref()},
+				[]vulkan.DeviceSize{vulkan.DeviceSize(vertexesOffset)},
+			)
+		}
+
+		if indexes != nil {
+			vulkan.CmdBindIndexBuffer(cb, indexes.Ref(), vulkan.DeviceSize(indexesOffset), vulkan.IndexTypeUint32)
+			vulkan.CmdDrawIndexed(cb, uint32(len(indices)), 1, 0, 0, 0)
+		} else {
+			vulkan.CmdDraw(cb, vertexCount, 1, 0, 0)
+		}
+
+		vlk.frameStats.DrawCalls++
+		vlk.frameStats.Vertices += vertexCount
+		vlk.frameStats.Batches++
+	})
+}
+
+type customSet struct {
+	index uint32 // layout(set = N)
+	ref   vulkan.DescriptorSet
+}
+
+// customDescriptorSets allocate descriptor sets for one draw of shader.
+// Data of uniform blocks is streamed into frame ring, and sets point
+// to it, so sets and data is valid only in current frame
+func (vlk *VLK) customDescriptorSets(frameID uint32, meta *shader.Meta, blocks []byte) []customSet {
+	reflection := meta.Reflection()
+	if reflection == nil || len(reflection.DescriptorSets) == 0 {
+		return nil
+	}
+
+	sets := make([]customSet, 0, len(reflection.DescriptorSets))
+	refs := make(map[uint32]vulkan.DescriptorSet, len(reflection.DescriptorSets))
+
+	for _, layout := range reflection.DescriptorSets {
+		ref := vlk.cont.descriptorPools().Allocate(frameID, vlk.cont.descriptorLayouts().Layout(layout.Bindings))
+
+		sets = append(sets, customSet{index: layout.Set, ref: ref})
+		refs[layout.Set] = ref
+	}
+
+	for _, block := range reflection.UniformBlocks {
+		info := vlk.cont.frameRing().WriteUniform(frameID, blocks[:block.Size])
+		blocks = blocks[block.Size:]
+
+		descriptor.WriteBuffer(vlk.cont.logicalDevice(), refs[block.Set], block.Binding, vulkan.DescriptorTypeUniformBuffer, info)
+	}
+
+	return sets
+}
@@ -0,0 +1,93 @@
+package vlk
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/vulkan-go/vulkan"
+
+	"github.com/go-glx/vgl/driver"
+	"github.com/go-glx/vgl/internal/gpu/vlk/internal/shader"
+)
+
+func TestCustomVertexCount(t *testing.T) {
+	bindings := []vulkan.VertexInputBindingDescription{
+		{Binding: 0, Stride: 20, InputRate: vulkan.VertexInputRateVertex},
+	}
+
+	tests := []struct {
+		name        string
+		bindings    []vulkan.VertexInputBindingDescription
+		vertexCount uint32
+		vertices    int // bytes in vertex buffer
+		valid       bool
+		want        uint32
+	}{
+		{name: "vertex buffer", bindings: bindings, vertices: 60, valid: true, want: 3},
+		{name: "no vertex input", vertexCount: 3, valid: true, want: 3},
+		{name: "no vertex input, vertices ignored", vertexCount: 6, vertices: 60, valid: true, want: 6},
+		{name: "no vertex input, without count", valid: false},
+		{name: "vertex buffer with count", bindings: bindings, vertexCount: 3, valid: false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := checkVertexCount(tt.bindings, tt.vertexCount)
+			if (err == nil) != tt.valid {
+				t.Fatalf("checkVertexCount() error = %v, want valid = %v", err, tt.valid)
+			}
+
+			if !tt.valid {
+				return
+			}
+
+			meta := shader.NewMeta("test", nil, nil, vulkan.PrimitiveTopologyTriangleList, tt.bindings, nil, nil).
+				WithVertexCount(tt.vertexCount)
+
+			if got := customVertexCount(meta, make([]byte, tt.vertices)); got != tt.want {
+				t.Errorf("customVertexCount() = %d, want %d", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestCheckCustomDraw(t *testing.T) {
+	bindings := []vulkan.VertexInputBindingDescription{
+		{Binding: 0, Stride: 20, InputRate: vulkan.VertexInputRateVertex},
+	}
+
+	meta := shader.NewMeta("test", nil, nil, vulkan.PrimitiveTopologyTriangleList, bindings, nil, nil).
+		WithPushConstantsSize(16)
+
+	tests := []struct {
+		name     string
+		vertices int // bytes in vertex buffer
+		indices  []uint32
+		uniforms int
+		valid    bool
+	}{
+		{name: "valid", vertices: 60, uniforms: 16, valid: true},
+		{name: "valid indexed", vertices: 60, indices: []uint32{0, 1, 2, 2, 1, 0}, uniforms: 16, valid: true},
+		{name: "vertices not multiple of stride", vertices: 50, uniforms: 16},
+		{name: "index out of vertices", vertices: 60, indices: []uint32{0, 1, 3}, uniforms: 16},
+		{name: "uniforms too small", vertices: 60, uniforms: 8},
+		{name: "uniforms too big", vertices: 60, uniforms: 32},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := checkCustomDraw(meta, make([]byte, tt.vertices), tt.indices, make([]byte, tt.uniforms))
+			if tt.valid {
+				if err != nil {
+					t.Fatalf("checkCustomDraw() error = %v, want valid", err)
+				}
+
+				return
+			}
+
+			if !errors.Is(err, driver.ErrInvalidDraw) {
+				t.Fatalf("checkCustomDraw() error = %v, want %v", err, driver.ErrInvalidDraw)
+			}
+		})
+	}
+}
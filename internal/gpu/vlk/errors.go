@@ -0,0 +1,35 @@
+package vlk
+
+import (
+	"github.com/go-glx/vgl/internal/gpu/vlk/internal/debugutils"
+	"github.com/go-glx/vgl/internal/gpu/vlk/internal/must"
+	"github.com/go-glx/vgl/internal/gpu/vlk/internal/physical"
+	"github.com/go-glx/vgl/internal/gpu/vlk/internal/shader"
+)
+
+// Error is failed vulkan call with result code
+type Error = must.Error
+
+// errors, that can be checked with errors.Is
+var (
+	ErrOutOfHostMemory      = must.ErrOutOfHostMemory
+	ErrOutOfDeviceMemory    = must.ErrOutOfDeviceMemory
+	ErrInitializationFailed = must.ErrInitializationFailed
+	ErrDeviceLost           = must.ErrDeviceLost
+	ErrLayerNotPresent      = must.ErrLayerNotPresent
+	ErrExtensionNotPresent  = must.ErrExtensionNotPresent
+	ErrFeatureNotPresent    = must.ErrFeatureNotPresent
+	ErrIncompatibleDriver   = must.ErrIncompatibleDriver
+	ErrFormatNotSupported   = must.ErrFormatNotSupported
+	ErrSurfaceLost          = must.ErrSurfaceLost
+	ErrNativeWindowInUse    = must.ErrNativeWindowInUse
+	ErrOutOfDate            = must.ErrOutOfDate
+	ErrOutOfPoolMemory      = must.ErrOutOfPoolMemory
+
+	ErrNoSuitableGPU  = physical.ErrNoSuitableGPU
+	ErrShaderNotFound = shader.ErrShaderNotFound
+	ErrValidation     = debugutils.ErrValidation
+
+	ErrShaderAlreadyRegistered = shader.ErrShaderAlreadyRegistered
+	ErrInvalidShader           = shader.ErrInvalidShader
+)
@@ -0,0 +1,58 @@
+package vlk
+
+import (
+	"fmt"
+	"log/slog"
+
+	"github.com/vulkan-go/vulkan"
+
+	"github.com/go-glx/vgl/config"
+	"github.com/go-glx/vgl/internal/gpu/vlk/internal/instance"
+	"github.com/go-glx/vgl/internal/gpu/vlk/internal/physical"
+)
+
+type (
+	GPUInfo    = physical.Info
+	MemoryHeap = physical.MemoryHeap
+	GPULimits  = physical.Limits
+)
+
+// ListGPUs return all vulkan GPU's in system. It's not require
+// window, vulkan loader is loaded from system library
+func ListGPUs(logger *slog.Logger) []GPUInfo {
+	if err := vulkan.SetDefaultGetInstanceProcAddr(); err != nil {
+		panic(fmt.Errorf("failed load vulkan library: %w", err))
+	}
+
+	if err := vulkan.Init(); err != nil {
+		panic(fmt.Errorf("failed init vulkan: %w", err))
+	}
+
+	inst := instance.NewInstance(
+		instance.NewCreateOptions(
+			logger.With(slog.String("module", "instance")),
+			"vgl",
+			"vgl",
+			nil,
+			false,
+			false,
+		),
+	)
+	defer inst.Free()
+
+	return physical.ListInfo(inst)
+}
+
+func gpuSelector(selector config.GPUSelector) physical.Selector {
+	types := make([]string, 0, len(selector.Types()))
+	for _, gpuType := range selector.Types() {
+		types = append(types, string(gpuType))
+	}
+
+	return physical.Selector{
+		ByIndex: selector.Index() >= 0,
+		Index:   selector.Index(),
+		Name:    selector.Name(),
+		Types:   types,
+	}
+}
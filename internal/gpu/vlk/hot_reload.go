@@ -0,0 +1,74 @@
+package vlk
+
+import (
+	"log/slog"
+	"time"
+
+	"github.com/go-glx/vgl/internal/gpu/vlk/internal/shader"
+)
+
+// reloadShaders will reload all shaders changed on
+// disk since previous check (see config.WithShaderHotReload)
+func (vlk *VLK) reloadShaders() {
+	shaders := vlk.cont.shaderManager()
+
+	metas := make([]*shader.Meta, 0, len(shaders.IDs()))
+	for _, id := range shaders.IDs() {
+		if sh, err := shaders.ShaderByID(id); err == nil {
+			metas = append(metas, sh.Meta())
+		}
+	}
+
+	for _, id := range vlk.shaderWatcher.Changed(metas, time.Now()) {
+		vlk.reloadShader(id)
+	}
+}
+
+// reloadShader replace shader modules and pipeline with new
+// bytecode version. New bytecode is reflected again, and should have
+// same interface. On any error previous version is still used
+func (vlk *VLK) reloadShader(id string) {
+	logger := vlk.cont.logger.With(slog.String("shader", id))
+	shaders := vlk.cont.shaderManager()
+
+	prev, err := shaders.ShaderByID(id)
+	if err != nil {
+		return
+	}
+
+	vert, err := vlk.shaderWatcher.Read(prev.Meta(), shader.TypeVertexBit)
+	if err != nil {
+		logger.Error("shader not reloaded, previous version is used", slog.Any("err", err))
+		return
+	}
+
+	frag, err := vlk.shaderWatcher.Read(prev.Meta(), shader.TypeFragmentBit)
+	if err != nil {
+		logger.Error("shader not reloaded, previous version is used", slog.Any("err", err))
+		return
+	}
+
+	meta, err := prev.Meta().Reload(vert, frag)
+	if err != nil {
+		logger.Error("shader not reloaded, previous version is used", slog.Any("err", err))
+		return
+	}
+
+	vlk.maintenance(func() {
+		next, err := shaders.Compile(meta)
+		if err != nil {
+			logger.Error("shader not reloaded, previous version is used", slog.Any("err", err))
+			return
+		}
+
+		err = vlk.cont.pipelineFactory().Recreate(id, vlk.shaderPipelineOpts(next)...)
+		if err != nil {
+			shaders.Destroy(next)
+			logger.Error("shader not reloaded, previous version is used", slog.Any("err", err))
+			return
+		}
+
+		shaders.Replace(next)
+		logger.Info("shader reloaded")
+	})
+}
@@ -0,0 +1,88 @@
+package buffer
+
+import (
+	"github.com/vulkan-go/vulkan"
+
+	"github.com/go-glx/vgl/internal/gpu/vlk/internal/command"
+	"github.com/go-glx/vgl/internal/gpu/vlk/internal/logical"
+	"github.com/go-glx/vgl/internal/gpu/vlk/internal/memory"
+	"github.com/go-glx/vgl/internal/gpu/vlk/internal/must"
+)
+
+// Device is device local buffer (VRAM), that not accessible
+// from CPU. Data is uploaded once on creation, through host
+// visible staging buffer and transfer command. Used for static
+// data, that drawn many times (meshes)
+type Device struct {
+	ld *logical.Device
+
+	ref    vulkan.Buffer
+	memory *memory.Allocation
+	size   int
+}
+
+// NewDevice create buffer with data. Upload will block
+// until GPU is done with copy
+func NewDevice(alloc *memory.Allocator, ld *logical.Device, transfer *command.Transfer, data []byte, usage vulkan.BufferUsageFlagBits) *Device {
+	staging := NewHost(alloc, ld, len(data), vulkan.BufferUsageTransferSrcBit)
+	defer staging.Free()
+
+	staging.Write(0, data)
+
+	var buffer vulkan.Buffer
+	must.Work(vulkan.CreateBuffer(ld.Ref(), &vulkan.BufferCreateInfo{
+		SType:       vulkan.StructureTypeBufferCreateInfo,
+		Size:        vulkan.DeviceSize(len(data)),
+		Usage:       vulkan.BufferUsageFlags(usage | vulkan.BufferUsageTransferDstBit),
+		SharingMode: vulkan.SharingModeExclusive,
+	}, nil, &buffer))
+
+	buff := &Device{
+		ld:     ld,
+		ref:    buffer,
+		memory: alloc.AllocateBuffer(buffer, vulkan.MemoryPropertyDeviceLocalBit),
+		size:   len(data),
+	}
+
+	transfer.Submit(func(cb vulkan.CommandBuffer) {
+		vulkan.CmdCopyBuffer(cb, staging.Ref(), buff.ref, 1, []vulkan.BufferCopy{{
+			SrcOffset: 0,
+			DstOffset: 0,
+			Size:      vulkan.DeviceSize(len(data)),
+		}})
+
+		// make copied data visible for next draws
+		vulkan.CmdPipelineBarrier(cb,
+			vulkan.PipelineStageFlags(vulkan.PipelineStageTransferBit),
+			vulkan.PipelineStageFlags(vulkan.PipelineStageVertexInputBit|vulkan.PipelineStageVertexShaderBit),
+			0,
+			0, nil,
+			1, []vulkan.BufferMemoryBarrier{{
+				SType:               vulkan.StructureTypeBufferMemoryBarrier,
+				SrcAccessMask:       vulkan.AccessFlags(vulkan.AccessTransferWriteBit),
+				DstAccessMask:       vulkan.AccessFlags(vulkan.AccessVertexAttributeReadBit | vulkan.AccessIndexReadBit | vulkan.AccessUniformReadBit),
+				SrcQueueFamilyIndex: vulkan.QueueFamilyIgnored,
+				DstQueueFamilyIndex: vulkan.QueueFamilyIgnored,
+				Buffer:              buff.ref,
+				Offset:              0,
+				Size:                vulkan.DeviceSize(vulkan.WholeSize),
+			}},
+			0, nil,
+		)
+	})
+
+	return buff
+}
+
+func (b *Device) Free() {
+	vulkan.DestroyBuffer(b.ld.Ref(), b.ref, nil)
+	b.memory.Free()
+}
+
+func (b *Device) Ref() vulkan.Buffer {
+	return b.ref
+}
+
+func (b *Device) Size() int {
+	return b.size
+}
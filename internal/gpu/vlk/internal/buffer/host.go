@@ -0,0 +1,86 @@
+package buffer
+
+import (
+	"fmt"
+
+	"github.com/vulkan-go/vulkan"
+
+	"github.com/go-glx/vgl/internal/gpu/vlk/internal/logical"
+	"github.com/go-glx/vgl/internal/gpu/vlk/internal/memory"
+	"github.com/go-glx/vgl/internal/gpu/vlk/internal/must"
+)
+
+const hostMemory = vulkan.MemoryPropertyHostVisibleBit | vulkan.MemoryPropertyHostCoherentBit
+
+// Host is host visible buffer, that always mapped
+// into CPU memory. Used for data, that changes every frame
+// (vertexes, indexes, uniforms). CPU should not write into buffer, while
+// GPU still reading it (use one buffer per frame in flight)
+type Host struct {
+	ld *logical.Device
+
+	ref    vulkan.Buffer
+	memory *memory.Allocation
+	size   int
+}
+
+func NewHost(alloc *memory.Allocator, ld *logical.Device, size int, usage vulkan.BufferUsageFlagBits) *Host {
+	return newHost(ld, size, usage, func(buffer vulkan.Buffer) *memory.Allocation {
+		return alloc.AllocateBuffer(buffer, hostMemory)
+	})
+}
+
+// NewFrameHost create host buffer in per-frame memory of frameID. Buffer
+// is valid only until next memory.Allocator Begin of frameID, and should
+// be freed before it
+func NewFrameHost(alloc *memory.Allocator, ld *logical.Device, frameID uint32, size int, usage vulkan.BufferUsageFlagBits) *Host {
+	return newHost(ld, size, usage, func(buffer vulkan.Buffer) *memory.Allocation {
+		return alloc.AllocateFrameBuffer(frameID, buffer, hostMemory)
+	})
+}
+
+func newHost(ld *logical.Device, size int, usage vulkan.BufferUsageFlagBits, bind func(buffer vulkan.Buffer) *memory.Allocation) *Host {
+	info := &vulkan.BufferCreateInfo{
+		SType:       vulkan.StructureTypeBufferCreateInfo,
+		Size:        vulkan.DeviceSize(size),
+		Usage:       vulkan.BufferUsageFlags(usage),
+		SharingMode: vulkan.SharingModeExclusive,
+	}
+
+	var buffer vulkan.Buffer
+	must.Work(vulkan.CreateBuffer(ld.Ref(), info, nil, &buffer))
+
+	return &Host{
+		ld:     ld,
+		ref:    buffer,
+		memory: bind(buffer),
+		size:   size,
+	}
+}
+
+func (b *Host) Free() {
+	vulkan.DestroyBuffer(b.ld.Ref(), b.ref, nil)
+	b.memory.Free()
+}
+
+func (b *Host) Ref() vulkan.Buffer {
+	return b.ref
+}
+
+func (b *Host) Size() int {
+	return b.size
+}
+
+// Mapped return CPU view of whole buffer memory
+func (b *Host) Mapped() []byte {
+	return b.memory.Mapped()[:b.size]
+}
+
+// Write copy data into mapped buffer memory at offset
+func (b *Host) Write(offset int, data []byte) {
+	if offset+len(data) > b.size {
+		panic(fmt.Errorf("buffer overflow: write %d bytes at %d, but size is %d", len(data), offset, b.size))
+	}
+
+	copy(b.Mapped()[offset:], data)
+}
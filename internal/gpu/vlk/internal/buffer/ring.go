@@ -0,0 +1,203 @@
+package buffer
+
+import (
+	"fmt"
+	"log/slog"
+
+	"github.com/vulkan-go/vulkan"
+
+	"github.com/go-glx/vgl/internal/gpu/vlk/internal/debugutils"
+	"github.com/go-glx/vgl/internal/gpu/vlk/internal/logical"
+	"github.com/go-glx/vgl/internal/gpu/vlk/internal/memory"
+)
+
+const initialRingSize = 256 * 1024
+
+// ring usage, one buffer is used for all kinds of streamed data
+const ringUsage = vulkan.BufferUsageVertexBufferBit |
+	vulkan.BufferUsageIndexBufferBit |
+	vulkan.BufferUsageUniformBufferBit
+
+type (
+	// Ring is per-frame linear buffer for data, that streamed to GPU
+	// every frame (vertexes, indexes, uniforms). Every frame in flight
+	// has own long-lived host buffer, CPU allocate only from buffers
+	// of current frame, GPU is already done with them (frame fence
+	// waited), so writes never race with GPU reads.
+	//
+	// When frame data not fit into buffer, rest of frame is placed into
+	// overflow buffers in per-frame memory of allocator (see
+	// memory.Allocator.AllocateFrame). On next Begin overflow is
+	// destroyed, and outgrown frame buffer is replaced by bigger one
+	Ring struct {
+		logger *slog.Logger
+		names  *debugutils.Names
+		alloc  *memory.Allocator
+		ld     *logical.Device
+
+		name         string
+		uniformAlign int // minUniformBufferOffsetAlignment of GPU
+		frames       []ringFrame
+	}
+
+	ringFrame struct {
+		buffer   *Host   // long-lived, lazy created
+		overflow []*Host // valid until next Begin, last one is current
+		cursor   ringCursor
+		size     int // size of next frame buffer
+		demand   int // bytes requested in frame (with alignment)
+	}
+
+	// ringCursor place allocations linearly inside buffer of capacity
+	ringCursor struct {
+		capacity int
+		offset   int // next free byte
+	}
+)
+
+func NewRing(logger *slog.Logger, names *debugutils.Names, alloc *memory.Allocator, ld *logical.Device, name string, uniformAlign, framesInFlight int) *Ring {
+	return &Ring{
+		logger: logger,
+		names:  names,
+		alloc:  alloc,
+		ld:     ld,
+		name:   name,
+		frames: make([]ringFrame, framesInFlight),
+
+		uniformAlign: max(uniformAlign, 1),
+	}
+}
+
+func (r *Ring) Free() {
+	for frameID := range r.frames {
+		frame := &r.frames[frameID]
+
+		r.freeOverflow(frame)
+		if frame.buffer != nil {
+			frame.buffer.Free()
+		}
+	}
+
+	r.logger.Debug("freed: ring buffer", slog.String("name", r.name))
+}
+
+// Begin should be called on frame start, after GPU is done with
+// previous usage of frameID, and before memory allocator Begin of
+// frameID (overflow memory will be reused). All previous allocations
+// of frame is invalid
+func (r *Ring) Begin(frameID uint32) {
+	frame := &r.frames[frameID]
+	r.freeOverflow(frame)
+
+	if frame.buffer != nil && frame.demand > frame.buffer.Size() {
+		frame.buffer.Free()
+		frame.buffer = nil
+		frame.size = ringSize(frame.size, frame.demand)
+
+		r.logger.Debug("ring buffer grown",
+			slog.String("name", r.name),
+			slog.Int("frame", int(frameID)),
+			slog.Int("size", frame.size),
+		)
+	}
+
+	frame.demand = 0
+	frame.cursor = ringCursor{}
+	if frame.buffer != nil {
+		frame.cursor.capacity = frame.buffer.Size()
+	}
+}
+
+// Allocate reserve size bytes in buffer of frameID, and return
+// buffer, offset of reserved range in it, and mapped memory
+// of range, CPU can write data directly into it
+func (r *Ring) Allocate(frameID uint32, size, align int) (*Host, int, []byte) {
+	frame := &r.frames[frameID]
+	frame.demand = alignUp(frame.demand, max(align, 1)) + size
+
+	if frame.buffer == nil {
+		frame.size = ringSize(frame.size, size)
+		frame.buffer = NewHost(r.alloc, r.ld, frame.size, ringUsage)
+		frame.cursor = ringCursor{capacity: frame.size}
+		r.names.Buffer(frame.buffer.Ref(), fmt.Sprintf("frame.%d.%s", frameID, r.name))
+	}
+
+	offset, ok := frame.cursor.place(size, align)
+	if !ok {
+		r.overflowBuffer(frameID, size)
+		offset, _ = frame.cursor.place(size, align)
+	}
+
+	current := frame.buffer
+	if len(frame.overflow) > 0 {
+		current = frame.overflow[len(frame.overflow)-1]
+	}
+
+	return current, offset, current.Mapped()[offset : offset+size]
+}
+
+// Write copy data into buffer of frameID (see Allocate)
+func (r *Ring) Write(frameID uint32, data []byte, align int) (*Host, int) {
+	buff, offset, mapped := r.Allocate(frameID, len(data), align)
+	copy(mapped, data)
+
+	return buff, offset
+}
+
+// WriteUniform copy uniform block data into buffer of frameID,
+// and return buffer info for writing into descriptor set
+func (r *Ring) WriteUniform(frameID uint32, data []byte) vulkan.DescriptorBufferInfo {
+	buff, offset := r.Write(frameID, data, r.uniformAlign)
+
+	return vulkan.DescriptorBufferInfo{
+		Buffer: buff.Ref(),
+		Offset: vulkan.DeviceSize(offset),
+		Range:  vulkan.DeviceSize(len(data)),
+	}
+}
+
+// overflowBuffer create temporary buffer in per-frame memory, for
+// rest of frame data. Full buffers is already used in frame commands
+func (r *Ring) overflowBuffer(frameID uint32, need int) {
+	frame := &r.frames[frameID]
+	size := ringSize(frame.cursor.capacity, need)
+
+	buff := NewFrameHost(r.alloc, r.ld, frameID, size, ringUsage)
+	r.names.Buffer(buff.Ref(), fmt.Sprintf("frame.%d.%s.overflow.%d", frameID, r.name, len(frame.overflow)))
+
+	frame.overflow = append(frame.overflow, buff)
+	frame.cursor = ringCursor{capacity: size}
+}
+
+// freeOverflow destroy temporary buffers of frame, their
+// memory is owned by allocator, and reset with frame memory
+func (r *Ring) freeOverflow(frame *ringFrame) {
+	for _, buff := range frame.overflow {
+		buff.Free()
+	}
+
+	frame.overflow = frame.overflow[:0]
+}
+
+// ringSize return next power of two size from current,
+// that can hold need bytes (at least initialRingSize)
+func ringSize(current, need int) int {
+	size := max(current, initialRingSize)
+	for size < need {
+		size *= 2
+	}
+
+	return size
+}
+
+// place return offset of allocation aligned to align,
+// or false, when it not fit into capacity
+func (c *ringCursor) place(size, align int) (int, bool) {
+	offset := alignUp(c.offset, max(align, 1))
+	if offset+size > c.capacity {
+		return 0, false
+	}
+
+	c.offset = offset + size
+	return offset, true
+}
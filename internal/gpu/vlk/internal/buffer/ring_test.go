@@ -0,0 +1,86 @@
+package buffer
+
+import "testing"
+
+func TestRingCursor_Place(t *testing.T) {
+	type place struct {
+		size, align int
+		offset      int
+		ok          bool
+	}
+
+	tests := []struct {
+		name     string
+		capacity int
+		places   []place
+	}{
+		{
+			name:     "packed",
+			capacity: 64,
+			places: []place{
+				{size: 16, align: 4, offset: 0, ok: true},
+				{size: 16, align: 4, offset: 16, ok: true},
+				{size: 32, align: 4, offset: 32, ok: true},
+				{size: 4, align: 4, ok: false},
+			},
+		},
+		{
+			name:     "uniform alignment",
+			capacity: 1024,
+			places: []place{
+				{size: 12, align: 4, offset: 0, ok: true},
+				{size: 64, align: 256, offset: 256, ok: true},
+				{size: 4, align: 0, offset: 320, ok: true},
+				{size: 64, align: 256, offset: 512, ok: true},
+			},
+		},
+		{
+			name:     "exhausted by alignment",
+			capacity: 256,
+			places: []place{
+				{size: 8, align: 4, offset: 0, ok: true},
+				{size: 16, align: 256, ok: false},
+				{size: 16, align: 8, offset: 8, ok: true},
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cursor := ringCursor{capacity: tt.capacity}
+
+			for ind, want := range tt.places {
+				offset, ok := cursor.place(want.size, want.align)
+				if ok != want.ok {
+					t.Fatalf("place #%d: ok = %v, want %v", ind, ok, want.ok)
+				}
+
+				if ok && offset != want.offset {
+					t.Fatalf("place #%d: offset = %d, want %d", ind, offset, want.offset)
+				}
+			}
+		})
+	}
+}
+
+func TestRingSize(t *testing.T) {
+	tests := []struct {
+		name    string
+		current int
+		need    int
+		want    int
+	}{
+		{name: "initial", current: 0, need: 16, want: initialRingSize},
+		{name: "fit", current: 2 * initialRingSize, need: initialRingSize, want: 2 * initialRingSize},
+		{name: "exact", current: initialRingSize, need: 2 * initialRingSize, want: 2 * initialRingSize},
+		{name: "grow", current: initialRingSize, need: 3*initialRingSize + 1, want: 4 * initialRingSize},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := ringSize(tt.current, tt.need); got != tt.want {
+				t.Errorf("ringSize(%d, %d) = %d, want %d", tt.current, tt.need, got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,217 @@
+package buffer
+
+import (
+	"encoding/binary"
+	"fmt"
+	"math"
+	"reflect"
+
+	"github.com/go-glx/vgl/glm"
+)
+
+// std140 is layout of Go type in uniform buffer (GLSL std140 rules):
+//   - float32, int32, uint32, bool: align 4
+//   - glm.Vec2: align 8, glm.Vec3 and glm.Vec4: align 16
+//   - glm.Mat4: 4 columns of vec4, align 16
+//   - arrays: every element aligned to 16
+//   - structs: align 16, size rounded up to 16
+//
+// Struct fields should be exported, other Go types is not supported
+type std140 struct {
+	kind  std140Kind
+	align int
+	size  int
+
+	// arrays
+	elem   *std140
+	length int
+	stride int
+
+	// structs
+	fields []std140Field
+}
+
+type std140Field struct {
+	index  int
+	offset int
+	layout *std140
+}
+
+type std140Kind uint8
+
+const (
+	std140Float std140Kind = iota
+	std140Int
+	std140Uint
+	std140Bool
+	std140Vector // glm vectors and matrices, flat list of float32
+	std140Array
+	std140Struct
+)
+
+var std140Vectors = map[reflect.Type]std140{
+	reflect.TypeOf(glm.Vec2{}): {kind: std140Vector, align: 8, size: glm.SizeOfVec2},
+	reflect.TypeOf(glm.Vec3{}): {kind: std140Vector, align: 16, size: glm.SizeOfVec3},
+	reflect.TypeOf(glm.Vec4{}): {kind: std140Vector, align: 16, size: glm.SizeOfVec4},
+	reflect.TypeOf(glm.Mat4{}): {kind: std140Vector, align: 16, size: glm.SizeOfMat4},
+}
+
+// EncodeStd140 return value in std140 layout, value should
+// be struct (or other type, supported in std140 layout)
+func EncodeStd140(value any) ([]byte, error) {
+	if value == nil {
+		return nil, fmt.Errorf("nil is not supported in std140 layout")
+	}
+
+	layout, err := newStd140(reflect.TypeOf(value))
+	if err != nil {
+		return nil, err
+	}
+
+	data := make([]byte, layout.size)
+	layout.encode(data, reflect.ValueOf(value))
+
+	return data, nil
+}
+
+// Std140 is typed std140 layout of T, layout is resolved once
+// and reused for every encode (no per-call reflection of type)
+type Std140[T any] struct {
+	layout *std140
+}
+
+// NewStd140 resolve layout of T, T should be std140 compatible
+// struct (or other type, supported in std140 layout)
+func NewStd140[T any]() (*Std140[T], error) {
+	var zero T
+
+	t := reflect.TypeOf(zero)
+	if t == nil {
+		return nil, fmt.Errorf("interface type is not supported in std140 layout")
+	}
+
+	layout, err := newStd140(t)
+	if err != nil {
+		return nil, err
+	}
+
+	return &Std140[T]{layout: layout}, nil
+}
+
+// Size of T in std140 layout, in bytes
+func (s *Std140[T]) Size() int {
+	return s.layout.size
+}
+
+// Encode write value into dst, dst should have at least Size bytes.
+// Padding between fields is zeroed
+func (s *Std140[T]) Encode(dst []byte, value T) {
+	dst = dst[:s.layout.size]
+
+	clear(dst)
+	s.layout.encode(dst, reflect.ValueOf(value))
+}
+
+func newStd140(t reflect.Type) (*std140, error) {
+	if vector, ok := std140Vectors[t]; ok {
+		return &vector, nil
+	}
+
+	switch t.Kind() {
+	case reflect.Float32:
+		return &std140{kind: std140Float, align: 4, size: 4}, nil
+	case reflect.Int32:
+		return &std140{kind: std140Int, align: 4, size: 4}, nil
+	case reflect.Uint32:
+		return &std140{kind: std140Uint, align: 4, size: 4}, nil
+	case reflect.Bool:
+		return &std140{kind: std140Bool, align: 4, size: 4}, nil
+	case reflect.Array:
+		elem, err := newStd140(t.Elem())
+		if err != nil {
+			return nil, fmt.Errorf("[%d]: %w", t.Len(), err)
+		}
+
+		stride := alignUp(elem.size, 16)
+		return &std140{
+			kind:   std140Array,
+			align:  alignUp(elem.align, 16),
+			size:   stride * t.Len(),
+			elem:   elem,
+			length: t.Len(),
+			stride: stride,
+		}, nil
+	case reflect.Struct:
+		layout := &std140{kind: std140Struct, align: 16}
+		offset := 0
+
+		for ind := 0; ind < t.NumField(); ind++ {
+			field := t.Field(ind)
+			if !field.IsExported() {
+				return nil, fmt.Errorf("%s.%s: field is not exported", t.Name(), field.Name)
+			}
+
+			fieldLayout, err := newStd140(field.Type)
+			if err != nil {
+				return nil, fmt.Errorf("%s.%s: %w", t.Name(), field.Name, err)
+			}
+
+			offset = alignUp(offset, fieldLayout.align)
+			layout.fields = append(layout.fields, std140Field{index: ind, offset: offset, layout: fieldLayout})
+			offset += fieldLayout.size
+		}
+
+		layout.size = alignUp(offset, 16)
+		return layout, nil
+	default:
+		return nil, fmt.Errorf("type %s is not supported in std140 layout", t)
+	}
+}
+
+// encode write value into dst, dst should have at least layout size
+func (l *std140) encode(dst []byte, value reflect.Value) {
+	switch l.kind {
+	case std140Float:
+		binary.LittleEndian.PutUint32(dst, math.Float32bits(float32(value.Float())))
+	case std140Int:
+		binary.LittleEndian.PutUint32(dst, uint32(int32(value.Int())))
+	case std140Uint:
+		binary.LittleEndian.PutUint32(dst, uint32(value.Uint()))
+	case std140Bool:
+		b := uint32(0)
+		if value.Bool() {
+			b = 1
+		}
+
+		binary.LittleEndian.PutUint32(dst, b)
+	case std140Vector:
+		// glm types is flat structs of float32 (matrix is 4 x Vec4)
+		l.encodeFloats(dst, value, 0)
+	case std140Array:
+		for ind := 0; ind < l.length; ind++ {
+			l.elem.encode(dst[ind*l.stride:], value.Index(ind))
+		}
+	case std140Struct:
+		for _, field := range l.fields {
+			field.layout.encode(dst[field.offset:], value.Field(field.index))
+		}
+	}
+}
+
+func (l *std140) encodeFloats(dst []byte, value reflect.Value, offset int) int {
+	// glm.Vec4 components is float64, always converted to float32
+	if value.Kind() == reflect.Float32 || value.Kind() == reflect.Float64 {
+		binary.LittleEndian.PutUint32(dst[offset:], math.Float32bits(float32(value.Float())))
+		return offset + 4
+	}
+
+	for ind := 0; ind < value.NumField(); ind++ {
+		offset = l.encodeFloats(dst, value.Field(ind), offset)
+	}
+
+	return offset
+}
+
+func alignUp(value, align int) int {
+	return (value + align - 1) / align * align
+}
@@ -0,0 +1,162 @@
+package buffer
+
+import (
+	"bytes"
+	"encoding/binary"
+	"math"
+	"reflect"
+	"testing"
+
+	"github.com/go-glx/vgl/glm"
+)
+
+type testLight struct {
+	Color     glm.Vec3
+	Intensity float32
+}
+
+type testCamera struct {
+	Proj    glm.Mat4
+	Offset  glm.Vec2
+	Time    float32
+	Frame   uint32
+	Lights  [2]testLight
+	Weights [3]float32
+	Visible bool
+}
+
+func TestStd140_Layout(t *testing.T) {
+	layout, err := newStd140(reflect.TypeOf(testCamera{}))
+	if err != nil {
+		t.Fatalf("failed create layout: %v", err)
+	}
+
+	// offsets from GLSL std140 rules (same as glslc reflection)
+	wantOffsets := []int{0, 64, 72, 76, 80, 112, 160}
+	for ind, field := range layout.fields {
+		if field.offset != wantOffsets[ind] {
+			t.Errorf("field %d: offset %d, want %d", ind, field.offset, wantOffsets[ind])
+		}
+	}
+
+	if layout.size != 176 {
+		t.Errorf("size %d, want 176", layout.size)
+	}
+}
+
+func TestStd140_Encode(t *testing.T) {
+	camera := testCamera{
+		Proj:    glm.Mat4Identity(),
+		Offset:  glm.Vec2{X: 1, Y: 2},
+		Time:    3,
+		Frame:   4,
+		Lights:  [2]testLight{{Color: glm.Vec3{R: 5}, Intensity: 6}, {Color: glm.Vec3{B: 7}, Intensity: 8}},
+		Weights: [3]float32{9, 10, 11},
+		Visible: true,
+	}
+
+	layout, err := newStd140(reflect.TypeOf(camera))
+	if err != nil {
+		t.Fatalf("failed create layout: %v", err)
+	}
+
+	data := make([]byte, layout.size)
+	layout.encode(data, reflect.ValueOf(camera))
+
+	float := func(offset int) float32 {
+		return math.Float32frombits(binary.LittleEndian.Uint32(data[offset:]))
+	}
+
+	floats := map[int]float32{
+		0:   1,  // Proj[0][0]
+		20:  1,  // Proj[1][1]
+		60:  1,  // Proj[3][3]
+		64:  1,  // Offset.X
+		68:  2,  // Offset.Y
+		72:  3,  // Time
+		80:  5,  // Lights[0].Color.R
+		92:  6,  // Lights[0].Intensity
+		104: 7,  // Lights[1].Color.B
+		108: 8,  // Lights[1].Intensity
+		112: 9,  // Weights[0]
+		128: 10, // Weights[1] (array stride 16)
+		144: 11, // Weights[2]
+	}
+
+	for offset, want := range floats {
+		if got := float(offset); got != want {
+			t.Errorf("float at %d = %v, want %v", offset, got, want)
+		}
+	}
+
+	if frame := binary.LittleEndian.Uint32(data[76:]); frame != 4 {
+		t.Errorf("frame = %d, want 4", frame)
+	}
+
+	if visible := binary.LittleEndian.Uint32(data[160:]); visible != 1 {
+		t.Errorf("visible = %d, want 1", visible)
+	}
+}
+
+func TestStd140_NotSupported(t *testing.T) {
+	tests := map[string]any{
+		"float64":    struct{ A float64 }{},
+		"slice":      struct{ A []float32 }{},
+		"unexported": struct{ a float32 }{},
+	}
+
+	for name, value := range tests {
+		t.Run(name, func(t *testing.T) {
+			if _, err := newStd140(reflect.TypeOf(value)); err == nil {
+				t.Errorf("expected error")
+			}
+		})
+	}
+}
+
+func TestEncodeStd140(t *testing.T) {
+	data, err := EncodeStd140(testLight{Color: glm.Vec3{G: 1}, Intensity: 2})
+	if err != nil {
+		t.Fatalf("failed encode: %v", err)
+	}
+
+	if len(data) != 16 {
+		t.Fatalf("size %d, want 16", len(data))
+	}
+
+	if intensity := math.Float32frombits(binary.LittleEndian.Uint32(data[12:])); intensity != 2 {
+		t.Errorf("intensity = %v, want 2", intensity)
+	}
+
+	if _, err = EncodeStd140(nil); err == nil {
+		t.Errorf("expected error for nil")
+	}
+}
+
+func TestStd140_Typed(t *testing.T) {
+	layout, err := NewStd140[testLight]()
+	if err != nil {
+		t.Fatalf("failed create layout: %v", err)
+	}
+
+	if layout.Size() != 16 {
+		t.Fatalf("size %d, want 16", layout.Size())
+	}
+
+	// memory of ring is reused between frames, padding should be zeroed
+	dst := bytes.Repeat([]byte{0xff}, 32)
+	layout.Encode(dst, testLight{Color: glm.Vec3{R: 1}, Intensity: 2})
+
+	want, _ := EncodeStd140(testLight{Color: glm.Vec3{R: 1}, Intensity: 2})
+	if !bytes.Equal(dst[:16], want) {
+		t.Errorf("encoded %v, want %v", dst[:16], want)
+	}
+
+	if dst[16] != 0xff {
+		t.Errorf("encode write after layout size")
+	}
+
+	if _, err := NewStd140[struct{ A []float32 }](); err == nil {
+		t.Errorf("expected error for not supported type")
+	}
+}
@@ -0,0 +1,53 @@
+package buffer
+
+import (
+	"fmt"
+
+	"github.com/vulkan-go/vulkan"
+)
+
+// UniformBuffer is typed uniform buffer. Value of T is written
+// in std140 layout (see Std140) into frame ring, so every frame in
+// flight has own copy of data, and it can be changed every draw.
+// Go struct fields should be declared in same order and types,
+// as uniform block in shader:
+//
+//	layout(set = 0, binding = 0) uniform Camera { mat4 proj; vec2 offset; float time; };
+//	type Camera struct { Proj glm.Mat4; Offset glm.Vec2; Time float32 }
+type UniformBuffer[T any] struct {
+	layout *Std140[T]
+	ring   *Ring
+}
+
+// NewUniformBuffer create uniform buffer over ring. T
+// should be std140 compatible struct, otherwise it will panic
+func NewUniformBuffer[T any](ring *Ring, name string) *UniformBuffer[T] {
+	layout, err := NewStd140[T]()
+	if err != nil {
+		panic(fmt.Errorf("uniform buffer '%s': %w", name, err))
+	}
+
+	return &UniformBuffer[T]{
+		layout: layout,
+		ring:   ring,
+	}
+}
+
+// Size of uniform block in bytes
+func (ub *UniformBuffer[T]) Size() int {
+	return ub.layout.Size()
+}
+
+// Write encode value directly into ring memory of frameID,
+// and return buffer info for writing into descriptor set.
+// Data is valid until next ring Begin of frameID
+func (ub *UniformBuffer[T]) Write(frameID uint32, value T) vulkan.DescriptorBufferInfo {
+	buff, offset, mapped := ub.ring.Allocate(frameID, ub.layout.Size(), ub.ring.uniformAlign)
+	ub.layout.Encode(mapped, value)
+
+	return vulkan.DescriptorBufferInfo{
+		Buffer: buff.Ref(),
+		Offset: vulkan.DeviceSize(offset),
+		Range:  vulkan.DeviceSize(ub.layout.Size()),
+	}
+}
@@ -1,26 +1,36 @@
 package command
 
 import (
-	"log"
+	"fmt"
+	"log/slog"
 
 	"github.com/vulkan-go/vulkan"
 
+	"github.com/go-glx/vgl/internal/gpu/vlk/internal/debugutils"
 	"github.com/go-glx/vgl/internal/gpu/vlk/internal/logical"
 	"github.com/go-glx/vgl/internal/gpu/vlk/internal/must"
 	"github.com/go-glx/vgl/internal/gpu/vlk/internal/physical"
 )
 
 type Pool struct {
-	pd *physical.Device
-	ld *logical.Device
+	logger *slog.Logger
+	pd     *physical.Device
+	ld     *logical.Device
 
 	ref     vulkan.CommandPool
 	buffers []vulkan.CommandBuffer
 }
 
-func NewPool(pd *physical.Device, ld *logical.Device) *Pool {
-	pool, buffers := createPool(pd, ld)
+// NewPool create command pool with one primary
+// command buffer for every frame in flight
+func NewPool(logger *slog.Logger, names *debugutils.Names, pd *physical.Device, ld *logical.Device, framesInFlight int) *Pool {
+	pool, buffers := createPool(pd, ld, uint32(framesInFlight))
+	for ind, buffer := range buffers {
+		names.CommandBuffer(buffer, fmt.Sprintf("frame.%d.commands", ind))
+	}
+
 	return &Pool{
+		logger:  logger,
 		pd:      pd,
 		ld:      ld,
 		ref:     pool,
@@ -32,7 +42,7 @@ func (p *Pool) Free() {
 	vulkan.FreeCommandBuffers(p.ld.Ref(), p.ref, uint32(len(p.buffers)), p.buffers)
 	vulkan.DestroyCommandPool(p.ld.Ref(), p.ref, nil)
 
-	log.Printf("vk: freed: command pool\n")
+	p.logger.Debug("freed: command pool")
 }
 
 func (p *Pool) BuffersCount() int {
@@ -43,7 +53,7 @@ func (p *Pool) CommandBuffer(ind int) vulkan.CommandBuffer {
 	return p.buffers[ind]
 }
 
-func createPool(pd *physical.Device, ld *logical.Device) (vulkan.CommandPool, []vulkan.CommandBuffer) {
+func createPool(pd *physical.Device, ld *logical.Device, buffersCount uint32) (vulkan.CommandPool, []vulkan.CommandBuffer) {
 	createInfo := &vulkan.CommandPoolCreateInfo{
 		SType:            vulkan.StructureTypeCommandPoolCreateInfo,
 		QueueFamilyIndex: pd.PrimaryGPU().Families.GraphicsFamilyId,
@@ -55,7 +65,6 @@ func createPool(pd *physical.Device, ld *logical.Device) (vulkan.CommandPool, []
 	must.Work(vulkan.CreateCommandPool(ld.Ref(), createInfo, nil, &pool))
 
 	// create buffers
-	buffersCount := pd.PrimaryGPU().SurfaceProps.ConcurrentBuffersCount()
 	allocInfo := &vulkan.CommandBufferAllocateInfo{
 		SType:              vulkan.StructureTypeCommandBufferAllocateInfo,
 		CommandPool:        pool,
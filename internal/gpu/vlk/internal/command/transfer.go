@@ -0,0 +1,83 @@
+package command
+
+import (
+	"log/slog"
+	"math"
+
+	"github.com/vulkan-go/vulkan"
+
+	"github.com/go-glx/vgl/internal/gpu/vlk/internal/logical"
+	"github.com/go-glx/vgl/internal/gpu/vlk/internal/must"
+	"github.com/go-glx/vgl/internal/gpu/vlk/internal/physical"
+)
+
+// Transfer is command pool for one-time commands outside of
+// frame (uploads into device local memory, etc..). Every
+// Submit will block until GPU is done with commands
+type Transfer struct {
+	logger *slog.Logger
+	ld     *logical.Device
+
+	ref   vulkan.CommandPool
+	fence vulkan.Fence
+}
+
+func NewTransfer(logger *slog.Logger, pd *physical.Device, ld *logical.Device) *Transfer {
+	var pool vulkan.CommandPool
+	must.Work(vulkan.CreateCommandPool(ld.Ref(), &vulkan.CommandPoolCreateInfo{
+		SType:            vulkan.StructureTypeCommandPoolCreateInfo,
+		QueueFamilyIndex: pd.PrimaryGPU().Families.GraphicsFamilyId,
+		Flags:            vulkan.CommandPoolCreateFlags(vulkan.CommandPoolCreateTransientBit),
+	}, nil, &pool))
+
+	var fence vulkan.Fence
+	must.Work(vulkan.CreateFence(ld.Ref(), &vulkan.FenceCreateInfo{
+		SType: vulkan.StructureTypeFenceCreateInfo,
+	}, nil, &fence))
+
+	return &Transfer{
+		logger: logger,
+		ld:     ld,
+		ref:    pool,
+		fence:  fence,
+	}
+}
+
+func (t *Transfer) Free() {
+	vulkan.DestroyFence(t.ld.Ref(), t.fence, nil)
+	vulkan.DestroyCommandPool(t.ld.Ref(), t.ref, nil)
+
+	t.logger.Debug("freed: transfer command pool")
+}
+
+// Submit record commands into new command buffer, submit
+// it into graphics queue and wait until it is executed
+func (t *Transfer) Submit(record func(cb vulkan.CommandBuffer)) {
+	buffers := make([]vulkan.CommandBuffer, 1)
+	must.Work(vulkan.AllocateCommandBuffers(t.ld.Ref(), &vulkan.CommandBufferAllocateInfo{
+		SType:              vulkan.StructureTypeCommandBufferAllocateInfo,
+		CommandPool:        t.ref,
+		Level:              vulkan.CommandBufferLevelPrimary,
+		CommandBufferCount: 1,
+	}, buffers))
+
+	defer vulkan.FreeCommandBuffers(t.ld.Ref(), t.ref, 1, buffers)
+
+	cb := buffers[0]
+	must.Work(vulkan.BeginCommandBuffer(cb, &vulkan.CommandBufferBeginInfo{
+		SType: vulkan.StructureTypeCommandBufferBeginInfo,
+		Flags: vulkan.CommandBufferUsageFlags(vulkan.CommandBufferUsageOneTimeSubmitBit),
+	}))
+
+	record(cb)
+
+	must.Work(vulkan.EndCommandBuffer(cb))
+	must.Work(vulkan.QueueSubmit(t.ld.QueueGraphics(), 1, []vulkan.SubmitInfo{{
+		SType:              vulkan.StructureTypeSubmitInfo,
+		CommandBufferCount: 1,
+		PCommandBuffers:    buffers,
+	}}, t.fence))
+
+	must.Work(vulkan.WaitForFences(t.ld.Ref(), 1, []vulkan.Fence{t.fence}, vulkan.True, math.MaxUint64))
+	must.Work(vulkan.ResetFences(t.ld.Ref(), 1, []vulkan.Fence{t.fence}))
+}
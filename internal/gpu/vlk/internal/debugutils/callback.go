@@ -0,0 +1,18 @@
+package debugutils
+
+/*
+#include "debug_utils.h"
+*/
+import "C"
+
+import "runtime/cgo"
+
+//export vglDebugUtilsMessage
+func vglDebugUtilsMessage(severity, types C.uint32_t, idName *C.char, idNumber C.int32_t, message *C.char, handle C.uintptr_t) {
+	m, ok := cgo.Handle(handle).Value().(*Messenger)
+	if !ok {
+		return
+	}
+
+	m.receive(uint32(severity), uint32(types), C.GoString(idName), C.GoString(message))
+}
@@ -0,0 +1,162 @@
+#include <stddef.h>
+#include "debug_utils.h"
+
+// see VK_EXT_debug_utils in vulkan_core.h
+
+#define VGL_STRUCTURE_TYPE_DEBUG_UTILS_OBJECT_NAME_INFO      1000128000
+#define VGL_STRUCTURE_TYPE_DEBUG_UTILS_LABEL                 1000128002
+#define VGL_STRUCTURE_TYPE_DEBUG_UTILS_MESSENGER_CREATE_INFO 1000128004
+
+typedef struct vglDebugUtilsLabel {
+    uint32_t    sType;
+    const void* pNext;
+    const char* pLabelName;
+    float       color[4];
+} vglDebugUtilsLabel;
+
+typedef struct vglDebugUtilsObjectNameInfo {
+    uint32_t    sType;
+    const void* pNext;
+    int32_t     objectType;
+    uint64_t    objectHandle;
+    const char* pObjectName;
+} vglDebugUtilsObjectNameInfo;
+
+typedef struct vglDebugUtilsMessengerCallbackData {
+    uint32_t                           sType;
+    const void*                        pNext;
+    uint32_t                           flags;
+    const char*                        pMessageIdName;
+    int32_t                            messageIdNumber;
+    const char*                        pMessage;
+    uint32_t                           queueLabelCount;
+    const vglDebugUtilsLabel*          pQueueLabels;
+    uint32_t                           cmdBufLabelCount;
+    const vglDebugUtilsLabel*          pCmdBufLabels;
+    uint32_t                           objectCount;
+    const vglDebugUtilsObjectNameInfo* pObjects;
+} vglDebugUtilsMessengerCallbackData;
+
+typedef vglBool32 (*vglDebugUtilsMessengerCallback)(
+    uint32_t severity,
+    uint32_t types,
+    const vglDebugUtilsMessengerCallbackData* data,
+    void* userData
+);
+
+typedef struct vglDebugUtilsMessengerCreateInfo {
+    uint32_t                       sType;
+    const void*                    pNext;
+    uint32_t                       flags;
+    uint32_t                       messageSeverity;
+    uint32_t                       messageType;
+    vglDebugUtilsMessengerCallback pfnUserCallback;
+    void*                          pUserData;
+} vglDebugUtilsMessengerCreateInfo;
+
+typedef void* (*vglGetInstanceProcAddrFn)(vglInstance instance, const char* name);
+typedef vglResult (*vglCreateDebugUtilsMessengerFn)(vglInstance, const vglDebugUtilsMessengerCreateInfo*, const void*, vglDebugUtilsMessenger*);
+typedef void (*vglDestroyDebugUtilsMessengerFn)(vglInstance, vglDebugUtilsMessenger, const void*);
+typedef vglResult (*vglSetDebugUtilsObjectNameFn)(vglDevice, const vglDebugUtilsObjectNameInfo*);
+typedef void (*vglCmdBeginDebugUtilsLabelFn)(vglCommandBuffer, const vglDebugUtilsLabel*);
+typedef void (*vglCmdEndDebugUtilsLabelFn)(vglCommandBuffer);
+
+// initialized by vulkan-go in vulkan.Init()
+extern vglGetInstanceProcAddrFn vgo_vkGetInstanceProcAddr;
+
+// implemented in go (callback.go)
+extern void vglDebugUtilsMessage(uint32_t severity, uint32_t types, char* idName, int32_t idNumber, char* message, uintptr_t handle);
+
+static vglBool32 vglDebugUtilsCallback(
+    uint32_t severity,
+    uint32_t types,
+    const vglDebugUtilsMessengerCallbackData* data,
+    void* userData
+) {
+    vglDebugUtilsMessage(
+        severity,
+        types,
+        (char*)data->pMessageIdName,
+        data->messageIdNumber,
+        (char*)data->pMessage,
+        (uintptr_t)userData
+    );
+
+    // application should always return false
+    return 0;
+}
+
+vglResult vglCreateDebugUtilsMessenger(vglInstance instance, uint32_t severity, uint32_t types, uintptr_t handle, vglDebugUtilsMessenger* messenger) {
+    if (vgo_vkGetInstanceProcAddr == NULL) {
+        return -7; // VK_ERROR_EXTENSION_NOT_PRESENT
+    }
+
+    vglCreateDebugUtilsMessengerFn create = (vglCreateDebugUtilsMessengerFn)
+        vgo_vkGetInstanceProcAddr(instance, "vkCreateDebugUtilsMessengerEXT");
+
+    if (create == NULL) {
+        return -7; // VK_ERROR_EXTENSION_NOT_PRESENT
+    }
+
+    vglDebugUtilsMessengerCreateInfo info = {
+        .sType           = VGL_STRUCTURE_TYPE_DEBUG_UTILS_MESSENGER_CREATE_INFO,
+        .pNext           = NULL,
+        .flags           = 0,
+        .messageSeverity = severity,
+        .messageType     = types,
+        .pfnUserCallback = vglDebugUtilsCallback,
+        .pUserData       = (void*)handle,
+    };
+
+    return create(instance, &info, NULL, messenger);
+}
+
+void vglDestroyDebugUtilsMessenger(vglInstance instance, vglDebugUtilsMessenger messenger) {
+    vglDestroyDebugUtilsMessengerFn destroy = (vglDestroyDebugUtilsMessengerFn)
+        vgo_vkGetInstanceProcAddr(instance, "vkDestroyDebugUtilsMessengerEXT");
+
+    if (destroy == NULL) {
+        return;
+    }
+
+    destroy(instance, messenger, NULL);
+}
+
+int vglLoadDebugUtils(vglInstance instance, vglDebugUtilsFns* fns) {
+    if (vgo_vkGetInstanceProcAddr == NULL) {
+        return 0;
+    }
+
+    fns->setObjectName = vgo_vkGetInstanceProcAddr(instance, "vkSetDebugUtilsObjectNameEXT");
+    fns->cmdBeginLabel = vgo_vkGetInstanceProcAddr(instance, "vkCmdBeginDebugUtilsLabelEXT");
+    fns->cmdEndLabel   = vgo_vkGetInstanceProcAddr(instance, "vkCmdEndDebugUtilsLabelEXT");
+
+    return fns->setObjectName != NULL && fns->cmdBeginLabel != NULL && fns->cmdEndLabel != NULL;
+}
+
+vglResult vglSetDebugUtilsObjectName(vglDebugUtilsFns* fns, vglDevice device, int32_t objectType, uint64_t handle, const char* name) {
+    vglDebugUtilsObjectNameInfo info = {
+        .sType        = VGL_STRUCTURE_TYPE_DEBUG_UTILS_OBJECT_NAME_INFO,
+        .pNext        = NULL,
+        .objectType   = objectType,
+        .objectHandle = handle,
+        .pObjectName  = name,
+    };
+
+    return ((vglSetDebugUtilsObjectNameFn)fns->setObjectName)(device, &info);
+}
+
+void vglCmdBeginDebugUtilsLabel(vglDebugUtilsFns* fns, vglCommandBuffer cb, const char* name, float r, float g, float b, float a) {
+    vglDebugUtilsLabel label = {
+        .sType      = VGL_STRUCTURE_TYPE_DEBUG_UTILS_LABEL,
+        .pNext      = NULL,
+        .pLabelName = name,
+        .color      = {r, g, b, a},
+    };
+
+    ((vglCmdBeginDebugUtilsLabelFn)fns->cmdBeginLabel)(cb, &label);
+}
+
+void vglCmdEndDebugUtilsLabel(vglDebugUtilsFns* fns, vglCommandBuffer cb) {
+    ((vglCmdEndDebugUtilsLabelFn)fns->cmdEndLabel)(cb);
+}
@@ -0,0 +1,56 @@
+// VK_EXT_debug_utils is not available in vulkan-go bindings,
+// so we declare required (ABI stable) structs here and load
+// extension functions with loader vkGetInstanceProcAddr,
+// that is already initialized by vulkan.Init()
+
+#ifndef VGL_DEBUG_UTILS_H
+#define VGL_DEBUG_UTILS_H
+
+#include <stdint.h>
+
+typedef uint32_t vglBool32;
+typedef int32_t vglResult;
+typedef void* vglInstance;
+typedef void* vglDevice;
+typedef void* vglCommandBuffer;
+typedef uint64_t vglDebugUtilsMessenger;
+
+// severity (VkDebugUtilsMessageSeverityFlagBitsEXT)
+#define VGL_SEVERITY_VERBOSE 0x00000001
+#define VGL_SEVERITY_INFO    0x00000010
+#define VGL_SEVERITY_WARNING 0x00000100
+#define VGL_SEVERITY_ERROR   0x00001000
+
+// type (VkDebugUtilsMessageTypeFlagBitsEXT)
+#define VGL_TYPE_GENERAL     0x00000001
+#define VGL_TYPE_VALIDATION  0x00000002
+#define VGL_TYPE_PERFORMANCE 0x00000004
+
+// object types (VkObjectType)
+#define VGL_OBJECT_TYPE_COMMAND_BUFFER        6
+#define VGL_OBJECT_TYPE_BUFFER                9
+#define VGL_OBJECT_TYPE_IMAGE                 10
+#define VGL_OBJECT_TYPE_IMAGE_VIEW            14
+#define VGL_OBJECT_TYPE_SHADER_MODULE         15
+#define VGL_OBJECT_TYPE_RENDER_PASS           18
+#define VGL_OBJECT_TYPE_PIPELINE              19
+#define VGL_OBJECT_TYPE_DESCRIPTOR_SET_LAYOUT 20
+#define VGL_OBJECT_TYPE_DESCRIPTOR_POOL       22
+#define VGL_OBJECT_TYPE_FRAMEBUFFER           24
+
+// device level functions, loaded once for instance
+typedef struct vglDebugUtilsFns {
+    void* setObjectName;
+    void* cmdBeginLabel;
+    void* cmdEndLabel;
+} vglDebugUtilsFns;
+
+vglResult vglCreateDebugUtilsMessenger(vglInstance instance, uint32_t severity, uint32_t types, uintptr_t handle, vglDebugUtilsMessenger* messenger);
+void vglDestroyDebugUtilsMessenger(vglInstance instance, vglDebugUtilsMessenger messenger);
+
+int vglLoadDebugUtils(vglInstance instance, vglDebugUtilsFns* fns);
+vglResult vglSetDebugUtilsObjectName(vglDebugUtilsFns* fns, vglDevice device, int32_t objectType, uint64_t handle, const char* name);
+void vglCmdBeginDebugUtilsLabel(vglDebugUtilsFns* fns, vglCommandBuffer cb, const char* name, float r, float g, float b, float a);
+void vglCmdEndDebugUtilsLabel(vglDebugUtilsFns* fns, vglCommandBuffer cb);
+
+#endif
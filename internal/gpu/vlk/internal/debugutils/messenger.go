@@ -0,0 +1,141 @@
+package debugutils
+
+/*
+#include "debug_utils.h"
+*/
+import "C"
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+	"runtime/cgo"
+	"sync"
+	"unsafe"
+
+	"github.com/vulkan-go/vulkan"
+
+	"github.com/go-glx/vgl/internal/gpu/vlk/internal/def"
+	"github.com/go-glx/vgl/internal/gpu/vlk/internal/instance"
+	"github.com/go-glx/vgl/internal/gpu/vlk/internal/must"
+)
+
+var ErrValidation = errors.New("vulkan validation failed")
+
+type (
+	// Messenger receive all messages from validation layers
+	// and write it to logger. Validation errors is also collected
+	// and can be checked with Failures (for example in tests)
+	Messenger struct {
+		logger *slog.Logger
+		inst   *instance.Instance
+
+		enabled bool
+		handle  cgo.Handle
+		ref     C.vglDebugUtilsMessenger
+
+		mux      sync.Mutex
+		failures []error
+	}
+
+	ValidationError struct {
+		ID      string
+		Message string
+	}
+)
+
+// NewMessenger create VK_EXT_debug_utils messenger. When extension
+// is not enabled in instance (not debug mode, or vulkan SDK is not
+// installed), messenger will do nothing
+func NewMessenger(logger *slog.Logger, inst *instance.Instance) *Messenger {
+	m := &Messenger{
+		logger: logger,
+		inst:   inst,
+	}
+
+	if !inst.HasExtension(def.DebugUtilsExtension) {
+		return m
+	}
+
+	m.handle = cgo.NewHandle(m)
+	must.Work(vulkan.Result(C.vglCreateDebugUtilsMessenger(
+		C.vglInstance(unsafe.Pointer(inst.Ref())),
+		C.VGL_SEVERITY_VERBOSE|C.VGL_SEVERITY_INFO|C.VGL_SEVERITY_WARNING|C.VGL_SEVERITY_ERROR,
+		C.VGL_TYPE_GENERAL|C.VGL_TYPE_VALIDATION|C.VGL_TYPE_PERFORMANCE,
+		C.uintptr_t(m.handle),
+		&m.ref,
+	)))
+
+	m.enabled = true
+	logger.Debug("debug messenger created")
+
+	return m
+}
+
+func (m *Messenger) Free() {
+	if !m.enabled {
+		return
+	}
+
+	C.vglDestroyDebugUtilsMessenger(C.vglInstance(unsafe.Pointer(m.inst.Ref())), m.ref)
+	m.handle.Delete()
+	m.enabled = false
+
+	m.logger.Debug("freed: debug messenger")
+}
+
+// Failures return all validation errors collected since last call
+func (m *Messenger) Failures() []error {
+	m.mux.Lock()
+	defer m.mux.Unlock()
+
+	failures := m.failures
+	m.failures = nil
+
+	return failures
+}
+
+func (m *Messenger) receive(severity, types uint32, id string, message string) {
+	level := slog.LevelDebug
+	switch {
+	case severity&C.VGL_SEVERITY_ERROR != 0:
+		level = slog.LevelError
+	case severity&C.VGL_SEVERITY_WARNING != 0:
+		level = slog.LevelWarn
+	case severity&C.VGL_SEVERITY_INFO != 0:
+		level = slog.LevelInfo
+	}
+
+	m.logger.Log(context.Background(), level, message,
+		slog.String("id", id),
+		slog.String("type", messageType(types)),
+	)
+
+	if level != slog.LevelError || types&C.VGL_TYPE_VALIDATION == 0 {
+		return
+	}
+
+	m.mux.Lock()
+	m.failures = append(m.failures, &ValidationError{ID: id, Message: message})
+	m.mux.Unlock()
+}
+
+func messageType(types uint32) string {
+	switch {
+	case types&C.VGL_TYPE_VALIDATION != 0:
+		return "validation"
+	case types&C.VGL_TYPE_PERFORMANCE != 0:
+		return "performance"
+	default:
+		return "general"
+	}
+}
+
+func (e *ValidationError) Error() string {
+	return fmt.Sprintf("%s: [%s] %s", ErrValidation, e.ID, e.Message)
+}
+
+func (e *ValidationError) Unwrap() error {
+	return ErrValidation
+}
@@ -0,0 +1,54 @@
+package debugutils
+
+import (
+	"bytes"
+	"errors"
+	"log/slog"
+	"strings"
+	"testing"
+)
+
+const (
+	severityInfo    = 0x00000010
+	severityWarning = 0x00000100
+	severityError   = 0x00001000
+
+	typeGeneral    = 0x00000001
+	typeValidation = 0x00000002
+)
+
+func TestMessenger_Receive(t *testing.T) {
+	var out bytes.Buffer
+	m := &Messenger{
+		logger: slog.New(slog.NewTextHandler(&out, &slog.HandlerOptions{Level: slog.LevelDebug})),
+	}
+
+	m.receive(severityInfo, typeGeneral, "Loader", "loader info")
+	m.receive(severityWarning, typeValidation, "UNASSIGNED-warn", "some warning")
+	m.receive(severityError, typeGeneral, "General-error", "not validation error")
+	m.receive(severityError, typeValidation, "VUID-vkCmdDraw-None-02700", "draw without pipeline")
+
+	logged := out.String()
+	for _, want := range []string{
+		"level=INFO msg=\"loader info\"",
+		"level=WARN msg=\"some warning\"",
+		"level=ERROR msg=\"draw without pipeline\" id=VUID-vkCmdDraw-None-02700 type=validation",
+	} {
+		if !strings.Contains(logged, want) {
+			t.Errorf("log should contain '%s', got:\n%s", want, logged)
+		}
+	}
+
+	failures := m.Failures()
+	if len(failures) != 1 {
+		t.Fatalf("expected only one validation failure, got %v", failures)
+	}
+
+	if !errors.Is(failures[0], ErrValidation) {
+		t.Fatalf("failure should be ErrValidation, got %v", failures[0])
+	}
+
+	if len(m.Failures()) != 0 {
+		t.Fatalf("failures should be reset after read")
+	}
+}
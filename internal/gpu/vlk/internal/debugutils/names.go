@@ -0,0 +1,134 @@
+package debugutils
+
+/*
+#include <stdlib.h>
+#include "debug_utils.h"
+*/
+import "C"
+
+import (
+	"log/slog"
+	"unsafe"
+
+	"github.com/vulkan-go/vulkan"
+
+	"github.com/go-glx/vgl/internal/gpu/vlk/internal/def"
+	"github.com/go-glx/vgl/internal/gpu/vlk/internal/instance"
+	"github.com/go-glx/vgl/internal/gpu/vlk/internal/logical"
+	"github.com/go-glx/vgl/internal/gpu/vlk/internal/must"
+)
+
+// Names will set debug names to vulkan objects and
+// emit command buffer labels, that visible in GPU debuggers
+// (RenderDoc, Nsight, etc..) and validation messages.
+// All methods is no-op, when debug utils is not enabled
+// in instance, also it safe to call methods on nil *Names
+type Names struct {
+	logger  *slog.Logger
+	ld      *logical.Device
+	enabled bool
+	fns     *C.vglDebugUtilsFns
+}
+
+func NewNames(logger *slog.Logger, inst *instance.Instance, ld *logical.Device) *Names {
+	n := &Names{logger: logger, ld: ld}
+
+	if !inst.HasExtension(def.DebugUtilsExtension) {
+		return n
+	}
+
+	// C memory, because pointer to fns is passed
+	// to C on every call
+	n.fns = (*C.vglDebugUtilsFns)(C.calloc(1, C.sizeof_vglDebugUtilsFns))
+	n.enabled = C.vglLoadDebugUtils(C.vglInstance(unsafe.Pointer(inst.Ref())), n.fns) == 1
+
+	return n
+}
+
+func (n *Names) Free() {
+	if n.fns == nil {
+		return
+	}
+
+	C.free(unsafe.Pointer(n.fns))
+	n.fns = nil
+	n.enabled = false
+}
+
+func (n *Names) Pipeline(ref vulkan.Pipeline, name string) {
+	n.set(C.VGL_OBJECT_TYPE_PIPELINE, unsafe.Pointer(ref), name)
+}
+
+func (n *Names) ShaderModule(ref vulkan.ShaderModule, name string) {
+	n.set(C.VGL_OBJECT_TYPE_SHADER_MODULE, unsafe.Pointer(ref), name)
+}
+
+func (n *Names) RenderPass(ref vulkan.RenderPass, name string) {
+	n.set(C.VGL_OBJECT_TYPE_RENDER_PASS, unsafe.Pointer(ref), name)
+}
+
+func (n *Names) Image(ref vulkan.Image, name string) {
+	n.set(C.VGL_OBJECT_TYPE_IMAGE, unsafe.Pointer(ref), name)
+}
+
+func (n *Names) ImageView(ref vulkan.ImageView, name string) {
+	n.set(C.VGL_OBJECT_TYPE_IMAGE_VIEW, unsafe.Pointer(ref), name)
+}
+
+func (n *Names) FrameBuffer(ref vulkan.Framebuffer, name string) {
+	n.set(C.VGL_OBJECT_TYPE_FRAMEBUFFER, unsafe.Pointer(ref), name)
+}
+
+func (n *Names) Buffer(ref vulkan.Buffer, name string) {
+	n.set(C.VGL_OBJECT_TYPE_BUFFER, unsafe.Pointer(ref), name)
+}
+
+func (n *Names) DescriptorSetLayout(ref vulkan.DescriptorSetLayout, name string) {
+	n.set(C.VGL_OBJECT_TYPE_DESCRIPTOR_SET_LAYOUT, unsafe.Pointer(ref), name)
+}
+
+func (n *Names) DescriptorPool(ref vulkan.DescriptorPool, name string) {
+	n.set(C.VGL_OBJECT_TYPE_DESCRIPTOR_POOL, unsafe.Pointer(ref), name)
+}
+
+func (n *Names) CommandBuffer(ref vulkan.CommandBuffer, name string) {
+	n.set(C.VGL_OBJECT_TYPE_COMMAND_BUFFER, unsafe.Pointer(ref), name)
+}
+
+// BeginLabel open named region in command buffer,
+// it should be closed with EndLabel
+func (n *Names) BeginLabel(cb vulkan.CommandBuffer, name string) {
+	if n == nil || !n.enabled {
+		return
+	}
+
+	cName := C.CString(name)
+	defer C.free(unsafe.Pointer(cName))
+
+	C.vglCmdBeginDebugUtilsLabel(n.fns, C.vglCommandBuffer(unsafe.Pointer(cb)), cName, 0, 0, 0, 0)
+}
+
+func (n *Names) EndLabel(cb vulkan.CommandBuffer) {
+	if n == nil || !n.enabled {
+		return
+	}
+
+	C.vglCmdEndDebugUtilsLabel(n.fns, C.vglCommandBuffer(unsafe.Pointer(cb)))
+}
+
+func (n *Names) set(objectType C.int32_t, handle unsafe.Pointer, name string) {
+	if n == nil || !n.enabled {
+		return
+	}
+
+	cName := C.CString(name)
+	defer C.free(unsafe.Pointer(cName))
+
+	must.NotCare(n.logger, vulkan.Result(C.vglSetDebugUtilsObjectName(
+		n.fns,
+		C.vglDevice(unsafe.Pointer(n.ld.Ref())),
+		objectType,
+		C.uint64_t(uintptr(handle)),
+		cName,
+	)))
+}
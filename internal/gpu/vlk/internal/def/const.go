@@ -18,6 +18,14 @@ var RequiredValidationLayers = []string{
 	"VK_LAYER_KHRONOS_validation",
 }
 
+// DebugUtilsExtension will be enabled in debug mode (when available)
+// for routing validation messages into logger
+const DebugUtilsExtension = "VK_EXT_debug_utils"
+
+// SwapchainColorspaceExtension will be enabled, when HDR output
+// is requested (and available). It's expose HDR surface color spaces
+const SwapchainColorspaceExtension = "VK_EXT_swapchain_colorspace"
+
 // ------------------------------------------------------
 // -- Device
 // ------------------------------------------------------
@@ -43,14 +51,50 @@ const OptimalSwapChainBuffersCount = 3
 
 // -- Format
 
-// What format/color space we want for rendering
-// If GPU not support this formats, render will panic
-// on initialization.
+// OutputTransform is conversion of linear shader output
+// into surface color space, that should be done in shaders
+type OutputTransform uint32
+
 const (
-	SurfaceFormat     = vulkan.FormatB8g8r8a8Srgb
-	SurfaceColorSpace = vulkan.ColorSpaceSrgbNonlinear
+	// OutputTransformNone - GPU encode output by itself (sRGB
+	// formats), or surface is linear (scRGB)
+	OutputTransformNone OutputTransform = 0
+
+	// OutputTransformSRGB - UNORM formats, GPU will not
+	// encode shader output into sRGB, so shaders should do it
+	OutputTransformSRGB OutputTransform = 1
+
+	// OutputTransformPQ - HDR10, shaders should convert output
+	// into BT.2020 primaries with ST 2084 (PQ) encoding
+	OutputTransformPQ OutputTransform = 2
 )
 
+// SurfaceFormat is acceptable format/color space of swapchain images
+type SurfaceFormat struct {
+	Format     vulkan.Format
+	ColorSpace vulkan.ColorSpace
+	Transform  OutputTransform
+	HDR        bool
+}
+
+// SurfaceFormats is ranked list of formats we want for rendering,
+// first format supported by GPU will be used. If GPU not support
+// any of this formats, it will not be used for rendering.
+var SurfaceFormats = []SurfaceFormat{
+	{Format: vulkan.FormatB8g8r8a8Srgb, ColorSpace: vulkan.ColorSpaceSrgbNonlinear},
+	{Format: vulkan.FormatR8g8b8a8Srgb, ColorSpace: vulkan.ColorSpaceSrgbNonlinear},
+	{Format: vulkan.FormatB8g8r8a8Unorm, ColorSpace: vulkan.ColorSpaceSrgbNonlinear, Transform: OutputTransformSRGB},
+	{Format: vulkan.FormatR8g8b8a8Unorm, ColorSpace: vulkan.ColorSpaceSrgbNonlinear, Transform: OutputTransformSRGB},
+}
+
+// HDRSurfaceFormats is ranked list of HDR formats, used when HDR
+// output is enabled. When GPU/display not support any of them,
+// SurfaceFormats will be used (SDR fallback)
+var HDRSurfaceFormats = []SurfaceFormat{
+	{Format: vulkan.FormatA2b10g10r10UnormPack32, ColorSpace: vulkan.ColorSpaceHdr10St2084, Transform: OutputTransformPQ, HDR: true},
+	{Format: vulkan.FormatR16g16b16a16Sfloat, ColorSpace: vulkan.ColorSpaceExtendedSrgbLinear, Transform: OutputTransformNone, HDR: true},
+}
+
 // ------------------------------------------------------
 // -- Rendering
 // ------------------------------------------------------
@@ -62,6 +106,14 @@ const (
 // application
 const FrameAcquireTimeout = time.Second * 3
 
+// ClearColor is linear RGBA color of empty frame
+var ClearColor = [4]float32{0, 0, 0, 0}
+
+// HDRPaperWhite is brightness of SDR white (1.0) in HDR10
+// output in nits, as recommended in BT.2408. Should be
+// same as paperWhite in shaders
+const HDRPaperWhite = 203.0
+
 // ShaderEntryPoint is entry point in shader bytecode
 // where GPU start executing shader code
 // do not change from "main"
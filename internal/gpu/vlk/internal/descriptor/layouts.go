@@ -0,0 +1,84 @@
+package descriptor
+
+import (
+	"fmt"
+	"log/slog"
+	"sort"
+	"strings"
+
+	"github.com/vulkan-go/vulkan"
+
+	"github.com/go-glx/vgl/internal/gpu/vlk/internal/debugutils"
+	"github.com/go-glx/vgl/internal/gpu/vlk/internal/logical"
+	"github.com/go-glx/vgl/internal/gpu/vlk/internal/must"
+)
+
+// LayoutCache create descriptor set layouts and share
+// them between all shaders with equal set bindings
+type LayoutCache struct {
+	logger *slog.Logger
+	names  *debugutils.Names
+	ld     *logical.Device
+
+	layouts map[string]vulkan.DescriptorSetLayout
+}
+
+func NewLayoutCache(logger *slog.Logger, names *debugutils.Names, ld *logical.Device) *LayoutCache {
+	return &LayoutCache{
+		logger: logger,
+		names:  names,
+		ld:     ld,
+
+		layouts: make(map[string]vulkan.DescriptorSetLayout),
+	}
+}
+
+func (c *LayoutCache) Free() {
+	for _, layout := range c.layouts {
+		vulkan.DestroyDescriptorSetLayout(c.ld.Ref(), layout, nil)
+	}
+
+	c.logger.Debug("freed: descriptor set layouts")
+}
+
+// Layout return descriptor set layout with bindings, layout
+// is created on first call and cached until cache is freed.
+// Empty bindings is valid layout (unused set between used sets)
+func (c *LayoutCache) Layout(bindings []vulkan.DescriptorSetLayoutBinding) vulkan.DescriptorSetLayout {
+	sorted := make([]vulkan.DescriptorSetLayoutBinding, len(bindings))
+	copy(sorted, bindings)
+	sort.Slice(sorted, func(i, j int) bool { return sorted[i].Binding < sorted[j].Binding })
+
+	key := layoutKey(sorted)
+	if layout, exist := c.layouts[key]; exist {
+		return layout
+	}
+
+	info := &vulkan.DescriptorSetLayoutCreateInfo{
+		SType:        vulkan.StructureTypeDescriptorSetLayoutCreateInfo,
+		BindingCount: uint32(len(sorted)),
+		PBindings:    sorted,
+	}
+
+	var layout vulkan.DescriptorSetLayout
+	must.Work(vulkan.CreateDescriptorSetLayout(c.ld.Ref(), info, nil, &layout))
+	c.names.DescriptorSetLayout(layout, "descriptor.layout."+key)
+
+	c.layouts[key] = layout
+	return layout
+}
+
+func layoutKey(bindings []vulkan.DescriptorSetLayoutBinding) string {
+	var key strings.Builder
+
+	for _, binding := range bindings {
+		key.WriteString(fmt.Sprintf("%d:%d:%d:%d;",
+			binding.Binding,
+			binding.DescriptorType,
+			binding.DescriptorCount,
+			binding.StageFlags,
+		))
+	}
+
+	return key.String()
+}
@@ -0,0 +1,162 @@
+package descriptor
+
+import (
+	"fmt"
+	"log/slog"
+
+	"github.com/vulkan-go/vulkan"
+
+	"github.com/go-glx/vgl/internal/gpu/vlk/internal/debugutils"
+	"github.com/go-glx/vgl/internal/gpu/vlk/internal/logical"
+	"github.com/go-glx/vgl/internal/gpu/vlk/internal/must"
+)
+
+// how many sets can be allocated from one pool
+const setsPerPool = 256
+
+// descriptors count in pool, per one set
+var poolSizes = []struct {
+	descriptorType vulkan.DescriptorType
+	perSet         uint32
+}{
+	{descriptorType: vulkan.DescriptorTypeUniformBuffer, perSet: 2},
+	{descriptorType: vulkan.DescriptorTypeCombinedImageSampler, perSet: 4},
+	{descriptorType: vulkan.DescriptorTypeStorageBuffer, perSet: 1},
+	{descriptorType: vulkan.DescriptorTypeSampledImage, perSet: 1},
+	{descriptorType: vulkan.DescriptorTypeStorageImage, perSet: 1},
+	{descriptorType: vulkan.DescriptorTypeSampler, perSet: 1},
+}
+
+type (
+	// Pools allocate descriptor sets, that used only in one frame.
+	// Every frame in flight has own pools, all sets of frame is
+	// freed at once on Begin, when GPU is done with this frame
+	Pools struct {
+		logger *slog.Logger
+		names  *debugutils.Names
+		ld     *logical.Device
+
+		frames []poolChain[vulkan.DescriptorPool]
+	}
+
+	// poolChain is list of pools of one frame. Allocations go
+	// to current pool, and move to next one, when current is full.
+	// Pools is created on demand and reused after reset
+	poolChain[P any] struct {
+		pools   []P
+		current int // index of pool used for next allocations
+	}
+)
+
+func NewPools(logger *slog.Logger, names *debugutils.Names, ld *logical.Device, framesInFlight int) *Pools {
+	return &Pools{
+		logger: logger,
+		names:  names,
+		ld:     ld,
+
+		frames: make([]poolChain[vulkan.DescriptorPool], framesInFlight),
+	}
+}
+
+func (p *Pools) Free() {
+	for _, frame := range p.frames {
+		for _, pool := range frame.pools {
+			vulkan.DestroyDescriptorPool(p.ld.Ref(), pool, nil)
+		}
+	}
+
+	p.logger.Debug("freed: descriptor pools")
+}
+
+// Begin should be called on frame start, after GPU is done
+// with previous usage of frameID. All sets allocated in
+// previous usage of frameID is freed and can't be used
+func (p *Pools) Begin(frameID uint32) {
+	p.frames[frameID].reset(func(pool vulkan.DescriptorPool) {
+		must.Work(vulkan.ResetDescriptorPool(p.ld.Ref(), pool, 0))
+	})
+}
+
+// Allocate return new descriptor set with layout, set is valid
+// until next Begin of frameID. New pool is created, when all
+// current frame pools are exhausted
+func (p *Pools) Allocate(frameID uint32, layout vulkan.DescriptorSetLayout) vulkan.DescriptorSet {
+	var set vulkan.DescriptorSet
+
+	p.frames[frameID].allocate(
+		func(index int) vulkan.DescriptorPool {
+			return p.createPool(frameID, index)
+		},
+		func(pool vulkan.DescriptorPool) bool {
+			result := vulkan.AllocateDescriptorSets(p.ld.Ref(), &vulkan.DescriptorSetAllocateInfo{
+				SType:              vulkan.StructureTypeDescriptorSetAllocateInfo,
+				DescriptorPool:     pool,
+				DescriptorSetCount: 1,
+				PSetLayouts:        []vulkan.DescriptorSetLayout{layout},
+			}, &set)
+
+			if result == vulkan.ErrorOutOfPoolMemory || result == vulkan.ErrorFragmentedPool {
+				// pool is full, try next one
+				return false
+			}
+
+			must.Work(result)
+			return true
+		},
+	)
+
+	return set
+}
+
+func (p *Pools) createPool(frameID uint32, index int) vulkan.DescriptorPool {
+	sizes := make([]vulkan.DescriptorPoolSize, 0, len(poolSizes))
+	for _, size := range poolSizes {
+		sizes = append(sizes, vulkan.DescriptorPoolSize{
+			Type:            size.descriptorType,
+			DescriptorCount: size.perSet * setsPerPool,
+		})
+	}
+
+	info := &vulkan.DescriptorPoolCreateInfo{
+		SType:         vulkan.StructureTypeDescriptorPoolCreateInfo,
+		MaxSets:       setsPerPool,
+		PoolSizeCount: uint32(len(sizes)),
+		PPoolSizes:    sizes,
+	}
+
+	var pool vulkan.DescriptorPool
+	must.Work(vulkan.CreateDescriptorPool(p.ld.Ref(), info, nil, &pool))
+	p.names.DescriptorPool(pool, fmt.Sprintf("frame.%d.descriptors.%d", frameID, index))
+
+	p.logger.Debug("descriptor pool allocated",
+		slog.Int("frame", int(frameID)),
+		slog.Int("index", index),
+	)
+
+	return pool
+}
+
+// allocate try allocate from current pool, and then from next pools
+// (created with create), until try return true
+func (c *poolChain[P]) allocate(create func(index int) P, try func(pool P) bool) {
+	for {
+		if c.current == len(c.pools) {
+			c.pools = append(c.pools, create(len(c.pools)))
+		}
+
+		if try(c.pools[c.current]) {
+			return
+		}
+
+		c.current++
+	}
+}
+
+// reset all pools, next allocations start from first pool
+func (c *poolChain[P]) reset(reset func(pool P)) {
+	for _, pool := range c.pools {
+		reset(pool)
+	}
+
+	c.current = 0
+}
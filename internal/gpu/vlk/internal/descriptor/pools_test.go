@@ -0,0 +1,74 @@
+package descriptor
+
+import "testing"
+
+// testPool is descriptor pool, that can hold capacity sets
+type testPool struct {
+	capacity int
+	used     int
+	resets   int
+}
+
+func TestPoolChain(t *testing.T) {
+	const capacity = 3
+
+	chain := poolChain[*testPool]{}
+	created := 0
+
+	create := func(index int) *testPool {
+		if index != created {
+			t.Fatalf("pool created with index %d, want %d", index, created)
+		}
+
+		created++
+		return &testPool{capacity: capacity}
+	}
+
+	try := func(pool *testPool) bool {
+		if pool.used == pool.capacity {
+			return false
+		}
+
+		pool.used++
+		return true
+	}
+
+	allocate := func(count int) {
+		for i := 0; i < count; i++ {
+			chain.allocate(create, try)
+		}
+	}
+
+	// grow: 7 sets need 3 pools
+	allocate(7)
+	if created != 3 || chain.current != 2 {
+		t.Fatalf("after grow: created %d pools (current %d), want 3 (current 2)", created, chain.current)
+	}
+
+	// reset: all pools is reused, new pools not created
+	chain.reset(func(pool *testPool) {
+		pool.used = 0
+		pool.resets++
+	})
+
+	if chain.current != 0 {
+		t.Fatalf("after reset: current pool %d, want 0", chain.current)
+	}
+
+	allocate(9)
+	if created != 3 {
+		t.Fatalf("after reset: created %d pools, want 3 (reused)", created)
+	}
+
+	for ind, pool := range chain.pools {
+		if pool.resets != 1 || pool.used != capacity {
+			t.Errorf("pool %d: resets=%d used=%d, want resets=1 used=%d", ind, pool.resets, pool.used, capacity)
+		}
+	}
+
+	// grow after reuse
+	allocate(1)
+	if created != 4 || chain.current != 3 {
+		t.Fatalf("after second grow: created %d pools (current %d), want 4 (current 3)", created, chain.current)
+	}
+}
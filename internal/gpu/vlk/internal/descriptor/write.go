@@ -0,0 +1,30 @@
+package descriptor
+
+import (
+	"github.com/vulkan-go/vulkan"
+
+	"github.com/go-glx/vgl/internal/gpu/vlk/internal/logical"
+)
+
+// WriteBuffer point binding of descriptor set to buffer
+// (uniform buffer, storage buffer). Set should not be
+// used in already recorded commands
+func WriteBuffer(
+	ld *logical.Device,
+	set vulkan.DescriptorSet,
+	binding uint32,
+	descriptorType vulkan.DescriptorType,
+	info vulkan.DescriptorBufferInfo,
+) {
+	vulkan.UpdateDescriptorSets(ld.Ref(), 1, []vulkan.WriteDescriptorSet{
+		{
+			SType:           vulkan.StructureTypeWriteDescriptorSet,
+			DstSet:          set,
+			DstBinding:      binding,
+			DstArrayElement: 0,
+			DescriptorCount: 1,
+			DescriptorType:  descriptorType,
+			PBufferInfo:     []vulkan.DescriptorBufferInfo{info},
+		},
+	}, 0, nil)
+}
@@ -1,23 +1,40 @@
 package frame
 
 import (
-	"log"
+	"image"
+	"log/slog"
 
 	"github.com/vulkan-go/vulkan"
 
 	"github.com/go-glx/vgl/internal/gpu/vlk/internal/command"
+	"github.com/go-glx/vgl/internal/gpu/vlk/internal/debugutils"
 	"github.com/go-glx/vgl/internal/gpu/vlk/internal/def"
 	"github.com/go-glx/vgl/internal/gpu/vlk/internal/logical"
+	"github.com/go-glx/vgl/internal/gpu/vlk/internal/memory"
 	"github.com/go-glx/vgl/internal/gpu/vlk/internal/must"
+	"github.com/go-glx/vgl/internal/gpu/vlk/internal/physical"
 	"github.com/go-glx/vgl/internal/gpu/vlk/internal/renderpass"
 	"github.com/go-glx/vgl/internal/gpu/vlk/internal/swapchain"
 )
 
+// vulkan calls of frame loop, replaced in tests
+var (
+	vkWaitForFences    = vulkan.WaitForFences
+	vkAcquireNextImage = vulkan.AcquireNextImage
+	vkQueueSubmit      = vulkan.QueueSubmit
+	vkQueuePresent     = vulkan.QueuePresent
+)
+
 type Manager struct {
+	logger         *slog.Logger
+	names          *debugutils.Names
 	chain          *swapchain.Chain
 	mainRenderPass *renderpass.Pass
 	ld             *logical.Device
 	onSuboptimal   func()
+	readback       *readback
+	timestamps     *timestamps
+	labels         int // opened debug labels in current frame
 
 	available bool
 	frameID   uint32
@@ -31,8 +48,10 @@ type Manager struct {
 	commandBuffers      map[uint32]vulkan.CommandBuffer
 }
 
-func NewManager(ld *logical.Device, pool *command.Pool, chain *swapchain.Chain, renderToScreenPass *renderpass.Pass, onSuboptimal func()) *Manager {
+func NewManager(logger *slog.Logger, names *debugutils.Names, pd *physical.Device, ld *logical.Device, alloc *memory.Allocator, pool *command.Pool, chain *swapchain.Chain, renderToScreenPass *renderpass.Pass, onSuboptimal func(), withReadback bool) *Manager {
 	m := &Manager{
+		logger:         logger,
+		names:          names,
 		chain:          chain,
 		mainRenderPass: renderToScreenPass,
 		ld:             ld,
@@ -57,7 +76,14 @@ func NewManager(ld *logical.Device, pool *command.Pool, chain *swapchain.Chain,
 		m.syncFrameBusy[fID] = allocateFence(ld)
 	}
 
-	log.Printf("vk: frame manager created\n")
+	m.timestamps = newTimestamps(pd, ld, m.count)
+
+	if withReadback {
+		m.readback = newReadback(alloc, ld, chain)
+		names.Buffer(m.readback.buffer.Ref(), "frame.readback")
+	}
+
+	logger.Debug("frame manager created", slog.Int("frames", int(m.count)))
 	return m
 }
 
@@ -68,7 +94,15 @@ func (m *Manager) Free() {
 		vulkan.DestroySemaphore(m.ld.Ref(), m.semRenderAvailable[fID], nil)
 	}
 
-	log.Printf("vk: freed: frames manager\n")
+	if m.readback != nil {
+		m.readback.free()
+	}
+
+	if m.timestamps != nil {
+		m.timestamps.free()
+	}
+
+	m.logger.Debug("freed: frames manager")
 }
 
 func (m *Manager) FrameBegin() {
@@ -79,8 +113,15 @@ func (m *Manager) FrameBegin() {
 	}
 
 	// start buffer
+	m.labels = 0
 	m.commandBufferBegin()
 
+	if m.timestamps != nil {
+		m.FrameApplyCommands(func(_ uint32, cb vulkan.CommandBuffer) {
+			m.timestamps.begin(m.frameID, cb)
+		})
+	}
+
 	// start render pass
 	m.FrameApplyCommands(func(imageID uint32, cb vulkan.CommandBuffer) {
 		m.renderPassMainBegin(imageID, cb)
@@ -94,12 +135,18 @@ func (m *Manager) prepareFrame() {
 
 	// wait for rendering in current frame is done
 	// then we can occupy current frame for next rendering
-	ok := must.NotCare(vulkan.WaitForFences(m.ld.Ref(), 1, []vulkan.Fence{renderDone}, vulkan.True, timeout))
+	ok := must.Frame(m.logger, vkWaitForFences(m.ld.Ref(), 1, []vulkan.Fence{renderDone}, vulkan.True, timeout))
 	if !ok {
 		m.available = false
 		return
 	}
 
+	// previous rendering in this frame is done, so GPU
+	// timings is available without stall
+	if m.timestamps != nil {
+		m.timestamps.collect(m.frameID)
+	}
+
 	// acquire new image
 	m.imageID, m.available = m.acquireNextImage()
 
@@ -110,7 +157,7 @@ func (m *Manager) prepareFrame() {
 
 	// wait when image will be available
 	if imageBusy, inFlight := m.syncImageBusy[m.imageID]; inFlight {
-		must.NotCare(vulkan.WaitForFences(m.ld.Ref(), 1, []vulkan.Fence{imageBusy}, vulkan.True, timeout))
+		must.Frame(m.logger, vkWaitForFences(m.ld.Ref(), 1, []vulkan.Fence{imageBusy}, vulkan.True, timeout))
 	}
 	m.syncImageBusy[m.imageID] = renderDone
 
@@ -118,6 +165,24 @@ func (m *Manager) prepareFrame() {
 	vulkan.ResetFences(m.ld.Ref(), 1, []vulkan.Fence{renderDone})
 }
 
+// Available return false, when current frame is skipped
+// (swapchain out of date, GPU timeout, etc..)
+func (m *Manager) Available() bool {
+	return m.available
+}
+
+// FrameID return index of current frame in flight. All per-frame
+// resources (buffers, etc..) should be indexed by this ID, GPU
+// is guaranteed to be done with previous usage of this frame
+func (m *Manager) FrameID() uint32 {
+	return m.frameID
+}
+
+// FramesCount return count of frames in flight
+func (m *Manager) FramesCount() uint32 {
+	return m.count
+}
+
 func (m *Manager) FrameApplyCommands(apply func(imageID uint32, cb vulkan.CommandBuffer)) {
 	if !m.available {
 		return
@@ -133,7 +198,23 @@ func (m *Manager) FrameEnd() {
 
 	// end render pass
 	m.FrameApplyCommands(func(imageID uint32, cb vulkan.CommandBuffer) {
+		if m.labels > 0 {
+			m.logger.Warn("debug group not closed in frame, auto closed", slog.Int("groups", m.labels))
+
+			for m.labels > 0 {
+				m.popLabel(cb)
+			}
+		}
+
 		m.renderPassMainEnd(cb)
+
+		if m.timestamps != nil {
+			m.timestamps.end(m.frameID, cb)
+		}
+
+		if m.readback != nil {
+			m.readback.record(cb, m.chain.Image(int(imageID)))
+		}
 	})
 
 	// end buffer
@@ -146,6 +227,51 @@ func (m *Manager) FrameEnd() {
 	m.nextFrame()
 }
 
+// PushLabel open debug label in current frame command buffer,
+// GPU time of label commands will be measured (when supported)
+func (m *Manager) PushLabel(name string) {
+	m.FrameApplyCommands(func(_ uint32, cb vulkan.CommandBuffer) {
+		m.names.BeginLabel(cb, name)
+		m.labels++
+
+		if m.timestamps != nil {
+			m.timestamps.pushGroup(m.frameID, cb, name)
+		}
+	})
+}
+
+// PopLabel close latest opened debug label in current frame
+func (m *Manager) PopLabel() {
+	m.FrameApplyCommands(func(_ uint32, cb vulkan.CommandBuffer) {
+		if m.labels <= 0 {
+			m.logger.Warn("debug group pop without push")
+			return
+		}
+
+		m.popLabel(cb)
+	})
+}
+
+func (m *Manager) popLabel(cb vulkan.CommandBuffer) {
+	if m.timestamps != nil {
+		m.timestamps.popGroup(m.frameID, cb)
+	}
+
+	m.names.EndLabel(cb)
+	m.labels--
+}
+
+// GPUTimings return latest available GPU timings (usually
+// from frame rendered N frames ago, where N is frames in flight).
+// Return empty timings, when GPU not support timestamps
+func (m *Manager) GPUTimings() Timings {
+	if m.timestamps == nil {
+		return Timings{}
+	}
+
+	return m.timestamps.last
+}
+
 func (m *Manager) nextFrame() {
 	m.frameID = (m.frameID + 1) % m.count
 }
@@ -159,14 +285,34 @@ func (m *Manager) submit() {
 		return
 	}
 
-	vulkan.QueueWaitIdle(m.ld.QueuePresent())
+	// CPU not wait for GPU here, next frames will be recorded
+	// while this frame still rendering. Readback is exception,
+	// we need copied image right after frame end
+	if m.readback != nil {
+		timeout := uint64(def.FrameAcquireTimeout.Nanoseconds())
+		renderDone := m.syncFrameBusy[m.frameID]
+
+		if must.Frame(m.logger, vkWaitForFences(m.ld.Ref(), 1, []vulkan.Fence{renderDone}, vulkan.True, timeout)) {
+			m.readback.collect()
+		}
+	}
+}
+
+// LastFrame return copy of latest rendered frame, or nil
+// when frame readback is not enabled or nothing rendered yet
+func (m *Manager) LastFrame() *image.RGBA {
+	if m.readback == nil {
+		return nil
+	}
+
+	return m.readback.last
 }
 
 func (m *Manager) acquireNextImage() (uint32, bool) {
 	timeout := uint64(def.FrameAcquireTimeout.Nanoseconds())
 	imageID := uint32(0)
 
-	result := vulkan.AcquireNextImage(m.ld.Ref(), m.chain.Ref(), timeout, m.semRenderAvailable[m.frameID], nil, &imageID)
+	result := vkAcquireNextImage(m.ld.Ref(), m.chain.Ref(), timeout, m.semRenderAvailable[m.frameID], nil, &imageID)
 	if result == vulkan.ErrorOutOfDate || result == vulkan.Suboptimal {
 		// buffer size changes (window rebuildGraphicsPipeline, minimize, etc..)
 		// and not more valid
@@ -174,8 +320,7 @@ func (m *Manager) acquireNextImage() (uint32, bool) {
 		return 0, false
 	}
 
-	if result != vulkan.Success {
-		must.NotCare(result)
+	if !must.Frame(m.logger, result) {
 		return 0, false
 	}
 
@@ -194,7 +339,7 @@ func (m *Manager) render() bool {
 		PSignalSemaphores:    []vulkan.Semaphore{m.semPresentAvailable[m.frameID]},
 	}
 
-	return must.NotCare(vulkan.QueueSubmit(m.ld.QueueGraphics(), 1, []vulkan.SubmitInfo{info}, m.syncFrameBusy[m.frameID]))
+	return must.Frame(m.logger, vkQueueSubmit(m.ld.QueueGraphics(), 1, []vulkan.SubmitInfo{info}, m.syncFrameBusy[m.frameID]))
 }
 
 func (m *Manager) present() bool {
@@ -207,5 +352,12 @@ func (m *Manager) present() bool {
 		PImageIndices:      []uint32{m.imageID},
 	}
 
-	return must.NotCare(vulkan.QueuePresent(m.ld.QueuePresent(), info))
+	result := vkQueuePresent(m.ld.QueuePresent(), info)
+	if result == vulkan.ErrorOutOfDate || result == vulkan.Suboptimal {
+		// frame is rendered, but swapchain should be rebuilt
+		m.onSuboptimal()
+		return false
+	}
+
+	return must.Frame(m.logger, result)
 }
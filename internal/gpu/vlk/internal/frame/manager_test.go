@@ -0,0 +1,132 @@
+package frame
+
+import (
+	"errors"
+	"io"
+	"log/slog"
+	"testing"
+
+	"github.com/vulkan-go/vulkan"
+
+	"github.com/go-glx/vgl/internal/gpu/vlk/internal/logical"
+	"github.com/go-glx/vgl/internal/gpu/vlk/internal/must"
+	"github.com/go-glx/vgl/internal/gpu/vlk/internal/swapchain"
+)
+
+// frameResults is injected results of frame loop vulkan calls
+type frameResults struct {
+	wait    vulkan.Result
+	acquire vulkan.Result
+	submit  vulkan.Result
+	present vulkan.Result
+}
+
+func newTestManager(t *testing.T, results frameResults, onSuboptimal func()) *Manager {
+	t.Helper()
+
+	prevWait, prevAcquire, prevSubmit, prevPresent := vkWaitForFences, vkAcquireNextImage, vkQueueSubmit, vkQueuePresent
+	t.Cleanup(func() {
+		vkWaitForFences, vkAcquireNextImage, vkQueueSubmit, vkQueuePresent = prevWait, prevAcquire, prevSubmit, prevPresent
+	})
+
+	vkWaitForFences = func(vulkan.Device, uint32, []vulkan.Fence, vulkan.Bool32, uint64) vulkan.Result {
+		return results.wait
+	}
+	vkAcquireNextImage = func(vulkan.Device, vulkan.Swapchain, uint64, vulkan.Semaphore, vulkan.Fence, *uint32) vulkan.Result {
+		return results.acquire
+	}
+	vkQueueSubmit = func(vulkan.Queue, uint32, []vulkan.SubmitInfo, vulkan.Fence) vulkan.Result {
+		return results.submit
+	}
+	vkQueuePresent = func(vulkan.Queue, *vulkan.PresentInfo) vulkan.Result {
+		return results.present
+	}
+
+	return &Manager{
+		logger:       slog.New(slog.NewTextHandler(io.Discard, nil)),
+		ld:           &logical.Device{},
+		chain:        &swapchain.Chain{},
+		onSuboptimal: onSuboptimal,
+		count:        2,
+	}
+}
+
+func TestManager_FatalResults(t *testing.T) {
+	tests := []struct {
+		name    string
+		results frameResults
+		call    func(m *Manager)
+		want    error
+	}{
+		{
+			name:    "fence device lost",
+			results: frameResults{wait: vulkan.ErrorDeviceLost},
+			call:    (*Manager).prepareFrame,
+			want:    must.ErrDeviceLost,
+		},
+		{
+			name:    "acquire out of memory",
+			results: frameResults{acquire: vulkan.ErrorOutOfDeviceMemory},
+			call:    (*Manager).prepareFrame,
+			want:    must.ErrOutOfDeviceMemory,
+		},
+		{
+			name:    "submit device lost",
+			results: frameResults{submit: vulkan.ErrorDeviceLost},
+			call:    (*Manager).submit,
+			want:    must.ErrDeviceLost,
+		},
+		{
+			name:    "present surface lost",
+			results: frameResults{present: vulkan.ErrorSurfaceLost},
+			call:    (*Manager).submit,
+			want:    must.ErrSurfaceLost,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			m := newTestManager(t, tt.results, func() {})
+
+			defer func() {
+				err, ok := recover().(error)
+				if !ok || !errors.Is(err, tt.want) {
+					t.Fatalf("expected panic with %v, got %v", tt.want, err)
+				}
+			}()
+
+			tt.call(m)
+		})
+	}
+}
+
+func TestManager_RecoverableResults(t *testing.T) {
+	t.Run("fence timeout skip frame", func(t *testing.T) {
+		m := newTestManager(t, frameResults{wait: vulkan.Timeout}, func() {})
+
+		m.prepareFrame()
+		if m.Available() {
+			t.Fatalf("frame should be skipped on fence timeout")
+		}
+	})
+
+	t.Run("acquire out of date rebuild swapchain", func(t *testing.T) {
+		rebuilds := 0
+		m := newTestManager(t, frameResults{acquire: vulkan.ErrorOutOfDate}, func() { rebuilds++ })
+
+		m.prepareFrame()
+		if m.Available() || rebuilds != 1 {
+			t.Fatalf("available=%v rebuilds=%d, want skipped frame and one rebuild", m.Available(), rebuilds)
+		}
+	})
+
+	t.Run("present suboptimal rebuild swapchain", func(t *testing.T) {
+		rebuilds := 0
+		m := newTestManager(t, frameResults{present: vulkan.Suboptimal}, func() { rebuilds++ })
+
+		m.submit()
+		if rebuilds != 1 {
+			t.Fatalf("rebuilds=%d, want 1", rebuilds)
+		}
+	})
+}
@@ -0,0 +1,151 @@
+package frame
+
+import (
+	"image"
+
+	"github.com/vulkan-go/vulkan"
+
+	"github.com/go-glx/vgl/internal/gpu/vlk/internal/buffer"
+	"github.com/go-glx/vgl/internal/gpu/vlk/internal/logical"
+	"github.com/go-glx/vgl/internal/gpu/vlk/internal/memory"
+	"github.com/go-glx/vgl/internal/gpu/vlk/internal/swapchain"
+)
+
+// readback will copy rendered swapchain image into
+// host visible buffer, after render pass is done.
+// This is used only in tests/debug for screenshots
+type readback struct {
+	width  uint32
+	height uint32
+	format vulkan.Format
+
+	buffer *buffer.Host
+
+	last *image.RGBA
+}
+
+func newReadback(alloc *memory.Allocator, ld *logical.Device, chain *swapchain.Chain) *readback {
+	props := chain.Props()
+	size := int(props.BufferSize.Width * props.BufferSize.Height * 4)
+
+	return &readback{
+		width:  props.BufferSize.Width,
+		height: props.BufferSize.Height,
+		format: props.ImageFormat,
+
+		buffer: buffer.NewHost(alloc, ld, size, vulkan.BufferUsageTransferDstBit),
+	}
+}
+
+func (r *readback) free() {
+	r.buffer.Free()
+}
+
+// record copy commands into command buffer.
+// Should be called after main render pass is ended,
+// when image is already in PresentSrc layout
+func (r *readback) record(cb vulkan.CommandBuffer, img vulkan.Image) {
+	subresource := vulkan.ImageSubresourceRange{
+		AspectMask:     vulkan.ImageAspectFlags(vulkan.ImageAspectColorBit),
+		BaseMipLevel:   0,
+		LevelCount:     1,
+		BaseArrayLayer: 0,
+		LayerCount:     1,
+	}
+
+	// PresentSrc -> TransferSrc
+	vulkan.CmdPipelineBarrier(cb,
+		vulkan.PipelineStageFlags(vulkan.PipelineStageColorAttachmentOutputBit),
+		vulkan.PipelineStageFlags(vulkan.PipelineStageTransferBit),
+		0,
+		0, nil,
+		0, nil,
+		1, []vulkan.ImageMemoryBarrier{{
+			SType:               vulkan.StructureTypeImageMemoryBarrier,
+			SrcAccessMask:       vulkan.AccessFlags(vulkan.AccessColorAttachmentWriteBit),
+			DstAccessMask:       vulkan.AccessFlags(vulkan.AccessTransferReadBit),
+			OldLayout:           vulkan.ImageLayoutPresentSrc,
+			NewLayout:           vulkan.ImageLayoutTransferSrcOptimal,
+			SrcQueueFamilyIndex: vulkan.QueueFamilyIgnored,
+			DstQueueFamilyIndex: vulkan.QueueFamilyIgnored,
+			Image:               img,
+			SubresourceRange:    subresource,
+		}},
+	)
+
+	vulkan.CmdCopyImageToBuffer(cb, img, vulkan.ImageLayoutTransferSrcOptimal, r.buffer.Ref(), 1, []vulkan.BufferImageCopy{{
+		BufferOffset:      0,
+		BufferRowLength:   0,
+		BufferImageHeight: 0,
+		ImageSubresource: vulkan.ImageSubresourceLayers{
+			AspectMask:     vulkan.ImageAspectFlags(vulkan.ImageAspectColorBit),
+			MipLevel:       0,
+			BaseArrayLayer: 0,
+			LayerCount:     1,
+		},
+		ImageOffset: vulkan.Offset3D{X: 0, Y: 0, Z: 0},
+		ImageExtent: vulkan.Extent3D{Width: r.width, Height: r.height, Depth: 1},
+	}})
+
+	// TransferSrc -> PresentSrc, and make buffer visible for host
+	vulkan.CmdPipelineBarrier(cb,
+		vulkan.PipelineStageFlags(vulkan.PipelineStageTransferBit),
+		vulkan.PipelineStageFlags(vulkan.PipelineStageHostBit|vulkan.PipelineStageBottomOfPipeBit),
+		0,
+		0, nil,
+		1, []vulkan.BufferMemoryBarrier{{
+			SType:               vulkan.StructureTypeBufferMemoryBarrier,
+			SrcAccessMask:       vulkan.AccessFlags(vulkan.AccessTransferWriteBit),
+			DstAccessMask:       vulkan.AccessFlags(vulkan.AccessHostReadBit),
+			SrcQueueFamilyIndex: vulkan.QueueFamilyIgnored,
+			DstQueueFamilyIndex: vulkan.QueueFamilyIgnored,
+			Buffer:              r.buffer.Ref(),
+			Offset:              0,
+			Size:                vulkan.DeviceSize(vulkan.WholeSize),
+		}},
+		1, []vulkan.ImageMemoryBarrier{{
+			SType:               vulkan.StructureTypeImageMemoryBarrier,
+			SrcAccessMask:       vulkan.AccessFlags(vulkan.AccessTransferReadBit),
+			DstAccessMask:       0,
+			OldLayout:           vulkan.ImageLayoutTransferSrcOptimal,
+			NewLayout:           vulkan.ImageLayoutPresentSrc,
+			SrcQueueFamilyIndex: vulkan.QueueFamilyIgnored,
+			DstQueueFamilyIndex: vulkan.QueueFamilyIgnored,
+			Image:               img,
+			SubresourceRange:    subresource,
+		}},
+	)
+}
+
+// collect will copy mapped GPU memory into go image.
+// Should be called only after GPU is done with
+// recorded copy commands (fence is signaled)
+func (r *readback) collect() {
+	size := int(r.width * r.height * 4)
+
+	img := image.NewRGBA(image.Rect(0, 0, int(r.width), int(r.height)))
+	copy(img.Pix, r.buffer.Mapped())
+
+	if isBGRA(r.format) {
+		for i := 0; i < size; i += 4 {
+			img.Pix[i], img.Pix[i+2] = img.Pix[i+2], img.Pix[i]
+		}
+	}
+
+	r.last = img
+}
+
+func isBGRA(format vulkan.Format) bool {
+	switch format {
+	case vulkan.FormatB8g8r8a8Unorm,
+		vulkan.FormatB8g8r8a8Snorm,
+		vulkan.FormatB8g8r8a8Uscaled,
+		vulkan.FormatB8g8r8a8Sscaled,
+		vulkan.FormatB8g8r8a8Uint,
+		vulkan.FormatB8g8r8a8Sint,
+		vulkan.FormatB8g8r8a8Srgb:
+		return true
+	default:
+		return false
+	}
+}
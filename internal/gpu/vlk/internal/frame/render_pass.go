@@ -1,6 +1,11 @@
 package frame
 
-import "github.com/vulkan-go/vulkan"
+import (
+	"github.com/vulkan-go/vulkan"
+
+	"github.com/go-glx/vgl/internal/gpu/vlk/internal/def"
+	"github.com/go-glx/vgl/internal/gpu/vlk/internal/vkconv"
+)
 
 func (m *Manager) renderPassMainBegin(imageID uint32, cb vulkan.CommandBuffer) {
 	renderPassBeginInfo := &vulkan.RenderPassBeginInfo{
@@ -19,7 +24,7 @@ func (m *Manager) renderPassMainBegin(imageID uint32, cb vulkan.CommandBuffer) {
 		},
 		ClearValueCount: 1,
 		PClearValues: []vulkan.ClearValue{
-			{0, 0, 0, 0},
+			m.clearColor(),
 		},
 	}
 
@@ -29,3 +34,23 @@ func (m *Manager) renderPassMainBegin(imageID uint32, cb vulkan.CommandBuffer) {
 func (m *Manager) renderPassMainEnd(cb vulkan.CommandBuffer) {
 	vulkan.CmdEndRenderPass(cb)
 }
+
+// clearColor return def.ClearColor in swapchain color space,
+// same output transform as in shaders is applied here
+func (m *Manager) clearColor() vulkan.ClearValue {
+	color := def.ClearColor
+
+	switch m.chain.Props().OutputTransform {
+	case def.OutputTransformSRGB:
+		for i := 0; i < 3; i++ {
+			color[i] = vkconv.LinearToSRGB(color[i])
+		}
+	case def.OutputTransformPQ:
+		wide := vkconv.BT709ToBT2020([3]float32{color[0], color[1], color[2]})
+		for i := 0; i < 3; i++ {
+			color[i] = vkconv.LinearToPQ(wide[i] * def.HDRPaperWhite)
+		}
+	}
+
+	return vulkan.NewClearValue(color[:])
+}
@@ -0,0 +1,209 @@
+package frame
+
+import (
+	"math"
+	"time"
+	"unsafe"
+
+	"github.com/vulkan-go/vulkan"
+
+	"github.com/go-glx/vgl/internal/gpu/vlk/internal/logical"
+	"github.com/go-glx/vgl/internal/gpu/vlk/internal/must"
+	"github.com/go-glx/vgl/internal/gpu/vlk/internal/physical"
+)
+
+// how many debug groups can be measured in single frame,
+// all groups above limit is not measured
+const maxTimestampGroups = 32
+
+// frame begin + frame end + (begin + end) for every group
+const timestampsPerFrame = 2 + maxTimestampGroups*2
+
+type (
+	// Timings is GPU execution time of frame, measured
+	// with timestamp queries. Timings is read without waiting
+	// GPU, so it always related to previous frames
+	Timings struct {
+		Frame  time.Duration
+		Groups []GroupTiming
+	}
+
+	GroupTiming struct {
+		Name     string
+		Duration time.Duration
+	}
+
+	// timestamps is query pool with separate block
+	// of queries for every frame in flight
+	timestamps struct {
+		ld     *logical.Device
+		pool   vulkan.QueryPool
+		period float64 // nanoseconds in one timestamp tick
+		mask   uint64  // valid bits of timestamp value
+
+		frames map[uint32]*frameTimestamps
+		last   Timings
+	}
+
+	frameTimestamps struct {
+		written uint32 // count of used queries
+		groups  []timestampGroup
+		opened  []int // stack of opened groups (index in groups or -1)
+	}
+
+	timestampGroup struct {
+		name  string
+		query uint32 // begin query, end = query+1
+	}
+)
+
+func newTimestamps(pd *physical.Device, ld *logical.Device, framesCount uint32) *timestamps {
+	gpu := pd.PrimaryGPU()
+	limits := gpu.Props.Limits
+	limits.Deref()
+
+	validBits := gpu.Families.GraphicsTimestampBits
+	if validBits == 0 || limits.TimestampPeriod <= 0 {
+		// not supported by graphics queue
+		return nil
+	}
+
+	info := &vulkan.QueryPoolCreateInfo{
+		SType:      vulkan.StructureTypeQueryPoolCreateInfo,
+		QueryType:  vulkan.QueryTypeTimestamp,
+		QueryCount: framesCount * timestampsPerFrame,
+	}
+
+	var pool vulkan.QueryPool
+	must.Work(vulkan.CreateQueryPool(ld.Ref(), info, nil, &pool))
+
+	ts := &timestamps{
+		ld:     ld,
+		pool:   pool,
+		period: float64(limits.TimestampPeriod),
+		mask:   timestampMask(validBits),
+		frames: make(map[uint32]*frameTimestamps),
+	}
+
+	for fID := uint32(0); fID < framesCount; fID++ {
+		ts.frames[fID] = &frameTimestamps{}
+	}
+
+	return ts
+}
+
+func (ts *timestamps) free() {
+	vulkan.DestroyQueryPool(ts.ld.Ref(), ts.pool, nil)
+}
+
+// begin should be called outside of render pass
+func (ts *timestamps) begin(frameID uint32, cb vulkan.CommandBuffer) {
+	frame := ts.frames[frameID]
+	frame.written = 2
+	frame.groups = frame.groups[:0]
+	frame.opened = frame.opened[:0]
+
+	first := frameID * timestampsPerFrame
+	vulkan.CmdResetQueryPool(cb, ts.pool, first, timestampsPerFrame)
+	vulkan.CmdWriteTimestamp(cb, vulkan.PipelineStageTopOfPipeBit, ts.pool, first)
+}
+
+func (ts *timestamps) end(frameID uint32, cb vulkan.CommandBuffer) {
+	first := frameID * timestampsPerFrame
+	vulkan.CmdWriteTimestamp(cb, vulkan.PipelineStageBottomOfPipeBit, ts.pool, first+1)
+}
+
+func (ts *timestamps) pushGroup(frameID uint32, cb vulkan.CommandBuffer, name string) {
+	frame := ts.frames[frameID]
+
+	if frame.written+2 > timestampsPerFrame {
+		// limit reached, group not measured
+		frame.opened = append(frame.opened, -1)
+		return
+	}
+
+	query := frame.written
+	frame.written += 2
+	frame.groups = append(frame.groups, timestampGroup{name: name, query: query})
+	frame.opened = append(frame.opened, len(frame.groups)-1)
+
+	vulkan.CmdWriteTimestamp(cb, vulkan.PipelineStageTopOfPipeBit, ts.pool, frameID*timestampsPerFrame+query)
+}
+
+func (ts *timestamps) popGroup(frameID uint32, cb vulkan.CommandBuffer) {
+	frame := ts.frames[frameID]
+	if len(frame.opened) == 0 {
+		return
+	}
+
+	ind := frame.opened[len(frame.opened)-1]
+	frame.opened = frame.opened[:len(frame.opened)-1]
+
+	if ind < 0 {
+		return
+	}
+
+	query := frame.groups[ind].query + 1
+	vulkan.CmdWriteTimestamp(cb, vulkan.PipelineStageBottomOfPipeBit, ts.pool, frameID*timestampsPerFrame+query)
+}
+
+// collect read results of frame, without waiting GPU.
+// Should be called after frame fence is signaled
+func (ts *timestamps) collect(frameID uint32) {
+	frame := ts.frames[frameID]
+	if frame.written == 0 {
+		// nothing recorded yet
+		return
+	}
+
+	results := make([]uint64, frame.written)
+	result := vulkan.GetQueryPoolResults(
+		ts.ld.Ref(),
+		ts.pool,
+		frameID*timestampsPerFrame,
+		frame.written,
+		uint(len(results)*8),
+		unsafe.Pointer(&results[0]),
+		8,
+		vulkan.QueryResultFlags(vulkan.QueryResult64Bit),
+	)
+
+	if result != vulkan.Success {
+		// not ready yet (or not written), keep previous timings
+		return
+	}
+
+	timings := Timings{
+		Frame:  ts.duration(results[0], results[1]),
+		Groups: make([]GroupTiming, 0, len(frame.groups)),
+	}
+
+	for _, group := range frame.groups {
+		timings.Groups = append(timings.Groups, GroupTiming{
+			Name:     group.name,
+			Duration: ts.duration(results[group.query], results[group.query+1]),
+		})
+	}
+
+	ts.last = timings
+}
+
+func (ts *timestamps) duration(begin, end uint64) time.Duration {
+	return time.Duration(float64(timestampTicks(begin, end, ts.mask)) * ts.period)
+}
+
+// timestampMask return mask of valid bits in timestamp value,
+// other bits of query result is undefined
+func timestampMask(validBits uint32) uint64 {
+	if validBits >= 64 {
+		return math.MaxUint64
+	}
+
+	return 1<<validBits - 1
+}
+
+// timestampTicks return ticks between timestamps, counter
+// can wrap around valid bits between begin and end
+func timestampTicks(begin, end, mask uint64) uint64 {
+	return (end - begin) & mask
+}
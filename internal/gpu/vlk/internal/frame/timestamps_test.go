@@ -0,0 +1,28 @@
+package frame
+
+import (
+	"math"
+	"testing"
+)
+
+func TestTimestampTicks(t *testing.T) {
+	tests := []struct {
+		name       string
+		validBits  uint32
+		begin, end uint64
+		want       uint64
+	}{
+		{name: "64 bits", validBits: 64, begin: 100, end: 250, want: 150},
+		{name: "garbage in invalid bits", validBits: 36, begin: 0xff00_0000_0000_0064, end: 0xab00_0000_0000_00fa, want: 150},
+		{name: "wrap around valid bits", validBits: 36, begin: 1<<36 - 50, end: 100, want: 150},
+		{name: "wrap around 64 bits", validBits: 64, begin: math.MaxUint64 - 49, end: 100, want: 150},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := timestampTicks(tt.begin, tt.end, timestampMask(tt.validBits)); got != tt.want {
+				t.Errorf("timestampTicks() = %d, want %d", got, tt.want)
+			}
+		})
+	}
+}
@@ -2,7 +2,8 @@ package instance
 
 import (
 	"fmt"
-	"log"
+	"log/slog"
+	"sort"
 	"strings"
 
 	"github.com/vulkan-go/vulkan"
@@ -15,7 +16,21 @@ type (
 	extList map[string]any
 )
 
-func fetchAvailableExtensions() extList {
+// AvailableExtensions return sorted names of all
+// instance extensions, available in vulkan loader
+func AvailableExtensions(logger *slog.Logger) []string {
+	available := fetchAvailableExtensions(logger)
+
+	names := make([]string, 0, len(available))
+	for name := range available {
+		names = append(names, name)
+	}
+
+	sort.Strings(names)
+	return names
+}
+
+func fetchAvailableExtensions(logger *slog.Logger) extList {
 	var extCount uint32
 	must.Work(vulkan.EnumerateInstanceExtensionProperties("", &extCount, nil))
 
@@ -35,7 +50,10 @@ func fetchAvailableExtensions() extList {
 			continue
 		}
 
-		log.Printf("vk: available ext: %s (v%d)\n", extName, extension.SpecVersion)
+		logger.Debug("available instance extension",
+			slog.String("ext", extName),
+			slog.Int("version", int(extension.SpecVersion)),
+		)
 		availableExt[extName] = struct{}{}
 	}
 
@@ -57,7 +75,8 @@ func assertRequiredExtensionsIsAvailable(available extList, required []string) {
 		return
 	}
 
-	panic(fmt.Errorf("vk: required extensions [%s] not available",
+	panic(fmt.Errorf("required extensions [%s] not available: %w",
 		strings.Join(notAvailable, ", "),
+		must.ErrExtensionNotPresent,
 	))
 }
@@ -1,7 +1,8 @@
 package instance
 
 import (
-	"log"
+	"log/slog"
+	"strings"
 
 	"github.com/vulkan-go/vulkan"
 
@@ -10,37 +11,57 @@ import (
 )
 
 type Instance struct {
-	ref vulkan.Instance
+	logger     *slog.Logger
+	ref        vulkan.Instance
+	extensions []string
 }
 
 func NewInstance(opt CreateOptions) *Instance {
+	ref, extensions := createVk(opt)
+
 	return &Instance{
-		ref: createVk(opt),
+		logger:     opt.logger,
+		ref:        ref,
+		extensions: extensions,
 	}
 }
 
 func (inst *Instance) Free() {
 	vulkan.DestroyInstance(inst.ref, nil)
 
-	log.Printf("vk: freed: vulkan instance\n")
+	inst.logger.Debug("freed: vulkan instance")
 }
 
 func (inst *Instance) Ref() vulkan.Instance {
 	return inst.ref
 }
 
-func createVk(opt CreateOptions) vulkan.Instance {
-	log.Printf("vk: init '%s' engine, required extensions: [%v]\n", opt.engineName, opt.requiredExtensions)
+// HasExtension return true, when extension is enabled in instance
+func (inst *Instance) HasExtension(name string) bool {
+	for _, ext := range inst.extensions {
+		if ext == name {
+			return true
+		}
+	}
+
+	return false
+}
+
+func createVk(opt CreateOptions) (vulkan.Instance, []string) {
+	opt.logger.Info("init vulkan instance",
+		slog.String("engine", opt.engineName),
+		slog.Any("requiredExtensions", opt.requiredExtensions),
+	)
 
-	info := createInfo(opt)
+	info, extensions := createInfo(opt)
 
 	var inst vulkan.Instance
 	must.Work(vulkan.CreateInstance(&info, nil, &inst))
 
-	return inst
+	return inst, extensions
 }
 
-func createInfo(opt CreateOptions) vulkan.InstanceCreateInfo {
+func createInfo(opt CreateOptions) (vulkan.InstanceCreateInfo, []string) {
 	info := vulkan.InstanceCreateInfo{
 		SType: vulkan.StructureTypeInstanceCreateInfo,
 		PApplicationInfo: &vulkan.ApplicationInfo{
@@ -54,15 +75,46 @@ func createInfo(opt CreateOptions) vulkan.InstanceCreateInfo {
 	}
 
 	// setup extensions
-	availableExt := fetchAvailableExtensions()
+	availableExt := fetchAvailableExtensions(opt.logger)
 	assertRequiredExtensionsIsAvailable(availableExt, opt.requiredExtensions)
-	info.PpEnabledExtensionNames = opt.requiredExtensions
+	extensions := append([]string{}, opt.requiredExtensions...)
+
+	if _, exist := availableExt[def.DebugUtilsExtension]; exist && opt.debugMode {
+		extensions = append(extensions, def.DebugUtilsExtension)
+	}
+
+	if opt.hdr {
+		if _, exist := availableExt[def.SwapchainColorspaceExtension]; exist {
+			extensions = append(extensions, def.SwapchainColorspaceExtension)
+		} else {
+			opt.logger.Warn("HDR output not available, fallback to SDR",
+				slog.String("missing", def.SwapchainColorspaceExtension),
+			)
+		}
+	}
+
+	info.PpEnabledExtensionNames = nullTerminated(extensions)
 	info.EnabledExtensionCount = uint32(len(info.PpEnabledExtensionNames))
 
 	// setup validation (debug)
-	validationLayers := validationLayers(opt.debugMode)
+	validationLayers := validationLayers(opt.logger, opt.debugMode)
 	info.EnabledLayerCount = uint32(len(validationLayers))
 	info.PpEnabledLayerNames = validationLayers
 
-	return info
+	return info, extensions
+}
+
+// nullTerminated make copy of names, that can be safely passed to C
+func nullTerminated(names []string) []string {
+	list := make([]string, 0, len(names))
+
+	for _, name := range names {
+		if !strings.HasSuffix(name, "\x00") {
+			name += "\x00"
+		}
+
+		list = append(list, name)
+	}
+
+	return list
 }
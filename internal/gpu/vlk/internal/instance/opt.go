@@ -1,22 +1,30 @@
 package instance
 
+import "log/slog"
+
 type CreateOptions struct {
+	logger             *slog.Logger
 	appName            string
 	engineName         string
 	requiredExtensions []string
 	debugMode          bool
+	hdr                bool
 }
 
 func NewCreateOptions(
+	logger *slog.Logger,
 	appName string,
 	engineName string,
 	requiredExtensions []string,
 	debugMode bool,
+	hdr bool,
 ) CreateOptions {
 	return CreateOptions{
+		logger:             logger,
 		appName:            appName,
 		engineName:         engineName,
 		requiredExtensions: requiredExtensions,
 		debugMode:          debugMode,
+		hdr:                hdr,
 	}
 }
@@ -1,7 +1,7 @@
 package instance
 
 import (
-	"log"
+	"log/slog"
 
 	"github.com/vulkan-go/vulkan"
 
@@ -10,7 +10,7 @@ import (
 	"github.com/go-glx/vgl/internal/gpu/vlk/internal/vkconv"
 )
 
-func validationLayers(isDebugMode bool) []string {
+func validationLayers(logger *slog.Logger, isDebugMode bool) []string {
 	if !isDebugMode {
 		return []string{}
 	}
@@ -41,11 +41,11 @@ func validationLayers(isDebugMode bool) []string {
 		found = append(found, layerName)
 	}
 
-	log.Printf("vk: available layers: [%v]\n", found)
+	logger.Debug("available validation layers", slog.Any("layers", found))
 
 	if len(notFound) > 0 {
-		log.Printf("vk: debug may not work (turn off it in engine config), because some of extensions not found: %v\n",
-			notFound,
+		logger.Warn("debug may not work (turn off it in engine config), because some of validation layers not found",
+			slog.Any("layers", notFound),
 		)
 	}
 
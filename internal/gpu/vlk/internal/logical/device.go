@@ -1,7 +1,7 @@
 package logical
 
 import (
-	"log"
+	"log/slog"
 
 	"github.com/vulkan-go/vulkan"
 
@@ -11,16 +11,18 @@ import (
 )
 
 type Device struct {
-	pd *physical.Device
+	logger *slog.Logger
+	pd     *physical.Device
 
 	ref           vulkan.Device
 	queueGraphics vulkan.Queue
 	queuePresent  vulkan.Queue
 }
 
-func NewDevice(pd *physical.Device) *Device {
+func NewDevice(logger *slog.Logger, pd *physical.Device) *Device {
 	dev := &Device{
-		pd: pd,
+		logger: logger,
+		pd:     pd,
 	}
 	dev.createLogicalAndEnrich()
 
@@ -41,7 +43,7 @@ func (dev *Device) QueuePresent() vulkan.Queue {
 
 func (dev *Device) Free() {
 	vulkan.DestroyDevice(dev.ref, nil)
-	log.Printf("vk: freed: logical device\n")
+	dev.logger.Debug("freed: logical device")
 }
 
 func (dev *Device) createLogicalAndEnrich() {
@@ -69,9 +71,9 @@ func (dev *Device) createLogicalAndEnrich() {
 	vulkan.GetDeviceQueue(logicalDevice, gpu.Families.PresentFamilyId, 0, &queuePresent)
 
 	// log
-	log.Printf("vk: logical device created (graphicsQ: %d, presentQ: %d)\n",
-		gpu.Families.GraphicsFamilyId,
-		gpu.Families.PresentFamilyId,
+	dev.logger.Debug("logical device created",
+		slog.Int("graphicsQ", int(gpu.Families.GraphicsFamilyId)),
+		slog.Int("presentQ", int(gpu.Families.PresentFamilyId)),
 	)
 
 	// enrich
@@ -0,0 +1,376 @@
+package memory
+
+import (
+	"fmt"
+	"log/slog"
+	"unsafe"
+
+	"github.com/vulkan-go/vulkan"
+
+	"github.com/go-glx/vgl/internal/gpu/vlk/internal/logical"
+	"github.com/go-glx/vgl/internal/gpu/vlk/internal/must"
+	"github.com/go-glx/vgl/internal/gpu/vlk/internal/physical"
+)
+
+const (
+	maxBlockSize   = 64 * 1024 * 1024
+	frameBlockSize = 4 * 1024 * 1024
+)
+
+type (
+	// Allocator carve buffers and images from big memory blocks,
+	// instead of allocating VkDeviceMemory for every resource
+	// (count of allocations is limited by maxMemoryAllocationCount).
+	//
+	// Long-lived resources is placed with free list, every memory
+	// type has own list of blocks. Per-frame resources is placed
+	// linearly into blocks of frame, that is reset in Begin.
+	Allocator struct {
+		logger *slog.Logger
+		ld     *logical.Device
+
+		memory         vulkan.PhysicalDeviceMemoryProperties
+		granularity    uint64
+		maxAllocations uint32
+
+		blocks map[uint32][]*block   // long-lived, by memory type
+		frames []map[uint32][]*block // per-frame, by memory type
+		count  uint32                // total allocated VkDeviceMemory
+	}
+
+	block struct {
+		memory     vulkan.DeviceMemory
+		memoryType uint32
+		size       uint64
+		mapped     unsafe.Pointer // nil, when memory is not host visible
+		algo       algorithm
+		dedicated  bool // allocated for single big resource
+	}
+
+	// Allocation is part of memory block, bound to single resource
+	Allocation struct {
+		alloc  *Allocator
+		block  *block
+		offset uint64
+		size   uint64
+		frame  bool
+	}
+)
+
+func NewAllocator(logger *slog.Logger, pd *physical.Device, ld *logical.Device, framesInFlight int) *Allocator {
+	gpu := pd.PrimaryGPU()
+
+	alloc := &Allocator{
+		logger:         logger,
+		ld:             ld,
+		memory:         gpu.Memory,
+		granularity:    max(gpu.Info.Limits.BufferImageGranularity, 1),
+		maxAllocations: gpu.Info.Limits.MaxMemoryAllocations,
+		blocks:         make(map[uint32][]*block),
+		frames:         make([]map[uint32][]*block, framesInFlight),
+	}
+
+	for frameID := range alloc.frames {
+		alloc.frames[frameID] = make(map[uint32][]*block)
+	}
+
+	return alloc
+}
+
+func (a *Allocator) Free() {
+	for _, blocks := range a.blocks {
+		for _, blk := range blocks {
+			a.freeBlock(blk)
+		}
+	}
+
+	for _, frame := range a.frames {
+		for _, blocks := range frame {
+			for _, blk := range blocks {
+				a.freeBlock(blk)
+			}
+		}
+	}
+
+	a.logger.Debug("freed: memory allocator")
+}
+
+// Begin should be called on frame start, after GPU is done
+// with previous usage of frameID. All per-frame allocations
+// of this frame become invalid. Dedicated blocks of oversized
+// allocations is freed, shared blocks is reset and reused
+func (a *Allocator) Begin(frameID uint32) {
+	frame := a.frames[frameID]
+
+	for memoryType, blocks := range frame {
+		shared := blocks[:0]
+
+		for _, blk := range blocks {
+			if blk.dedicated {
+				a.freeBlock(blk)
+				continue
+			}
+
+			blk.algo.reset()
+			shared = append(shared, blk)
+		}
+
+		clear(blocks[len(shared):])
+		frame[memoryType] = shared
+	}
+}
+
+// AllocateBuffer allocate long-lived memory for buffer and bind it
+func (a *Allocator) AllocateBuffer(buffer vulkan.Buffer, flags vulkan.MemoryPropertyFlagBits) *Allocation {
+	var req vulkan.MemoryRequirements
+	vulkan.GetBufferMemoryRequirements(a.ld.Ref(), buffer, &req)
+	req.Deref()
+
+	alloc := a.Allocate(req, flags, KindLinear)
+	must.Work(vulkan.BindBufferMemory(a.ld.Ref(), buffer, alloc.block.memory, vulkan.DeviceSize(alloc.offset)))
+
+	return alloc
+}
+
+// Allocate long-lived memory, that should be freed with Allocation.Free.
+// Images with optimal tiling should use KindOptimal, so they not share
+// bufferImageGranularity page with buffers (see Kind)
+func (a *Allocator) Allocate(req vulkan.MemoryRequirements, flags vulkan.MemoryPropertyFlagBits, kind Kind) *Allocation {
+	memoryType := a.memoryType(req.MemoryTypeBits, flags)
+	return a.allocate(a.blocks, memoryType, a.blockSize(memoryType), req, kind, func(size uint64) algorithm {
+		return newFreeList(size, a.granularity)
+	})
+}
+
+// AllocateFrameBuffer allocate per-frame memory for buffer and bind it
+// (see AllocateFrame). Buffer should be destroyed before next Begin of frameID
+func (a *Allocator) AllocateFrameBuffer(frameID uint32, buffer vulkan.Buffer, flags vulkan.MemoryPropertyFlagBits) *Allocation {
+	var req vulkan.MemoryRequirements
+	vulkan.GetBufferMemoryRequirements(a.ld.Ref(), buffer, &req)
+	req.Deref()
+
+	alloc := a.AllocateFrame(frameID, req, flags, KindLinear)
+	must.Work(vulkan.BindBufferMemory(a.ld.Ref(), buffer, alloc.block.memory, vulkan.DeviceSize(alloc.offset)))
+
+	return alloc
+}
+
+// AllocateFrame allocate memory, that is valid until
+// next Begin of frameID. Allocation.Free is not required.
+// Allocations bigger than half of frame block get own block,
+// that is freed in Begin
+func (a *Allocator) AllocateFrame(frameID uint32, req vulkan.MemoryRequirements, flags vulkan.MemoryPropertyFlagBits, kind Kind) *Allocation {
+	memoryType := a.memoryType(req.MemoryTypeBits, flags)
+	blockSize := min(frameBlockSize, a.blockSize(memoryType))
+	alloc := a.allocate(a.frames[frameID], memoryType, blockSize, req, kind, func(size uint64) algorithm {
+		return newLinear(size, a.granularity)
+	})
+
+	alloc.frame = true
+	return alloc
+}
+
+func (a *Allocator) allocate(
+	pool map[uint32][]*block,
+	memoryType uint32,
+	blockSize uint64,
+	req vulkan.MemoryRequirements,
+	kind Kind,
+	newAlgo func(size uint64) algorithm,
+) *Allocation {
+	size, align := uint64(req.Size), uint64(req.Alignment)
+
+	for _, blk := range pool[memoryType] {
+		if offset, ok := blk.algo.alloc(size, align, kind); ok {
+			return &Allocation{alloc: a, block: blk, offset: offset, size: size}
+		}
+	}
+
+	// big resources get own block, so they
+	// not waste space in shared blocks
+	dedicated := size > blockSize/2
+	if dedicated {
+		blockSize = size
+	}
+
+	blk := a.allocateBlock(memoryType, blockSize, newAlgo(blockSize))
+	blk.dedicated = dedicated
+	pool[memoryType] = append(pool[memoryType], blk)
+
+	offset, ok := blk.algo.alloc(size, align, kind)
+	if !ok {
+		panic(fmt.Errorf("failed place %d bytes into new memory block of %d bytes", size, blk.size))
+	}
+
+	return &Allocation{alloc: a, block: blk, offset: offset, size: size}
+}
+
+func (a *Allocator) allocateBlock(memoryType uint32, size uint64, algo algorithm) *block {
+	if a.maxAllocations > 0 && a.count >= a.maxAllocations {
+		panic(fmt.Errorf("failed allocate memory block: limit of %d device allocations reached: %w",
+			a.maxAllocations, must.ErrTooManyObjects))
+	}
+
+	var memory vulkan.DeviceMemory
+	must.Work(vulkan.AllocateMemory(a.ld.Ref(), &vulkan.MemoryAllocateInfo{
+		SType:           vulkan.StructureTypeMemoryAllocateInfo,
+		AllocationSize:  vulkan.DeviceSize(size),
+		MemoryTypeIndex: memoryType,
+	}, nil, &memory))
+
+	blk := &block{
+		memory:     memory,
+		memoryType: memoryType,
+		size:       size,
+		algo:       algo,
+	}
+
+	// host visible blocks is persistently mapped, because
+	// one VkDeviceMemory can't be mapped more than once
+	if a.hostVisible(memoryType) {
+		must.Work(vulkan.MapMemory(a.ld.Ref(), memory, 0, vulkan.DeviceSize(size), 0, &blk.mapped))
+	}
+
+	a.count++
+	a.logger.Debug("memory block allocated",
+		slog.Int("type", int(memoryType)),
+		slog.Uint64("size", size),
+		slog.Int("blocks", int(a.count)),
+	)
+
+	return blk
+}
+
+func (a *Allocator) freeBlock(blk *block) {
+	if blk.mapped != nil {
+		vulkan.UnmapMemory(a.ld.Ref(), blk.memory)
+	}
+
+	vulkan.FreeMemory(a.ld.Ref(), blk.memory, nil)
+	a.count--
+}
+
+// release long-lived allocation. Empty blocks is freed, except
+// last shared block of memory type, it will be reused
+func (a *Allocator) release(alloc *Allocation) {
+	blk := alloc.block
+	blk.algo.free(alloc.offset)
+
+	if blk.algo.count() > 0 {
+		return
+	}
+
+	blocks := a.blocks[blk.memoryType]
+	if !blk.dedicated && len(blocks) == 1 {
+		return
+	}
+
+	for ind, candidate := range blocks {
+		if candidate == blk {
+			a.blocks[blk.memoryType] = append(blocks[:ind], blocks[ind+1:]...)
+			break
+		}
+	}
+
+	a.freeBlock(blk)
+}
+
+// Stats return usage of all allocated memory
+func (a *Allocator) Stats() Stats {
+	stats := Stats{}
+
+	collect := func(pool map[uint32][]*block) {
+		for _, blocks := range pool {
+			for _, blk := range blocks {
+				stats.Blocks++
+				stats.Allocations += blk.algo.count()
+				stats.Reserved += blk.size
+				stats.Used += blk.algo.used()
+			}
+		}
+	}
+
+	collect(a.blocks)
+	for _, frame := range a.frames {
+		collect(frame)
+	}
+
+	return stats
+}
+
+// memoryType return first memory type with all flags. Host visible
+// memory should be requested with HostCoherent flag, allocator
+// never flush mapped memory (vulkan guarantee, that at least one
+// host visible memory type is coherent)
+func (a *Allocator) memoryType(typeBits uint32, flags vulkan.MemoryPropertyFlagBits) uint32 {
+	hostVisible := flags&vulkan.MemoryPropertyHostVisibleBit != 0
+	if hostVisible && flags&vulkan.MemoryPropertyHostCoherentBit == 0 {
+		panic(fmt.Errorf("host visible memory without HostCoherent flag is not supported (mapped memory is never flushed)"))
+	}
+
+	for ind := uint32(0); ind < a.memory.MemoryTypeCount; ind++ {
+		if typeBits&(1<<ind) == 0 {
+			continue
+		}
+
+		memType := a.memory.MemoryTypes[ind]
+		memType.Deref()
+
+		if memType.PropertyFlags&vulkan.MemoryPropertyFlags(flags) == vulkan.MemoryPropertyFlags(flags) {
+			return ind
+		}
+	}
+
+	panic(fmt.Errorf("failed find GPU memory type with flags %#x (resource type bits %#b): %w", flags, typeBits, must.ErrFeatureNotPresent))
+}
+
+func (a *Allocator) hostVisible(memoryType uint32) bool {
+	memType := a.memory.MemoryTypes[memoryType]
+	memType.Deref()
+
+	return memType.PropertyFlags&vulkan.MemoryPropertyFlags(vulkan.MemoryPropertyHostVisibleBit) != 0
+}
+
+// blockSize is 64MB, or 1/8 of heap for small heaps
+func (a *Allocator) blockSize(memoryType uint32) uint64 {
+	memType := a.memory.MemoryTypes[memoryType]
+	memType.Deref()
+
+	heap := a.memory.MemoryHeaps[memType.HeapIndex]
+	heap.Deref()
+
+	return min(maxBlockSize, uint64(heap.Size)/8)
+}
+
+// Free return long-lived allocation back to allocator.
+// Resource should be destroyed before. Per-frame
+// allocations is ignored, they freed in Allocator.Begin
+func (a *Allocation) Free() {
+	if a.frame {
+		return
+	}
+
+	a.alloc.release(a)
+}
+
+func (a *Allocation) Memory() vulkan.DeviceMemory {
+	return a.block.memory
+}
+
+func (a *Allocation) Offset() uint64 {
+	return a.offset
+}
+
+func (a *Allocation) Size() uint64 {
+	return a.size
+}
+
+// Mapped return CPU view of allocation memory, or
+// nil, when memory is not host visible
+func (a *Allocation) Mapped() []byte {
+	if a.block.mapped == nil {
+		return nil
+	}
+
+	return unsafe.Slice((*byte)(unsafe.Add(a.block.mapped, a.offset)), a.size)
+}
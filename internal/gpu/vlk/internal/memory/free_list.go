@@ -0,0 +1,144 @@
+package memory
+
+import (
+	"fmt"
+	"sort"
+)
+
+// freeList is first-fit allocator for long-lived resources.
+// Block is described as sorted list of spans (used and free),
+// that covers whole block. Neighbour free spans is always
+// merged, so fragmentation is kept low
+type freeList struct {
+	size        uint64
+	granularity uint64
+
+	spans     []span
+	usedBytes uint64
+	allocs    int
+}
+
+type span struct {
+	offset uint64
+	size   uint64
+	free   bool
+	kind   Kind
+}
+
+func newFreeList(size, granularity uint64) *freeList {
+	return &freeList{
+		size:        size,
+		granularity: granularity,
+		spans:       []span{{offset: 0, size: size, free: true}},
+	}
+}
+
+func (f *freeList) alloc(size, align uint64, kind Kind) (uint64, bool) {
+	if size == 0 {
+		return 0, false
+	}
+
+	for ind, free := range f.spans {
+		if !free.free || free.size < size {
+			continue
+		}
+
+		offset, ok := f.fit(ind, size, align, kind)
+		if !ok {
+			continue
+		}
+
+		f.place(ind, offset, size, kind)
+		return offset, true
+	}
+
+	return 0, false
+}
+
+// fit return offset of allocation inside free span,
+// or false, when allocation not fit into it
+func (f *freeList) fit(ind int, size, align uint64, kind Kind) (uint64, bool) {
+	free := f.spans[ind]
+	offset := alignUp(free.offset, align)
+
+	// free spans is always merged, so neighbours is used spans
+	if ind > 0 {
+		prev := f.spans[ind-1]
+		if prev.kind != kind && onSamePage(prev.offset, prev.size, offset, f.granularity) {
+			offset = alignUp(offset, f.granularity)
+		}
+	}
+
+	if offset+size > free.offset+free.size {
+		return 0, false
+	}
+
+	if ind < len(f.spans)-1 {
+		next := f.spans[ind+1]
+		if next.kind != kind && onSamePage(offset, size, next.offset, f.granularity) {
+			return 0, false
+		}
+	}
+
+	return offset, true
+}
+
+// place split free span into [padding] [allocation] [rest]
+func (f *freeList) place(ind int, offset, size uint64, kind Kind) {
+	free := f.spans[ind]
+	parts := make([]span, 0, 3)
+
+	if offset > free.offset {
+		parts = append(parts, span{offset: free.offset, size: offset - free.offset, free: true})
+	}
+
+	parts = append(parts, span{offset: offset, size: size, kind: kind})
+
+	if end := free.offset + free.size; offset+size < end {
+		parts = append(parts, span{offset: offset + size, size: end - (offset + size), free: true})
+	}
+
+	f.spans = append(f.spans[:ind], append(parts, f.spans[ind+1:]...)...)
+	f.usedBytes += size
+	f.allocs++
+}
+
+func (f *freeList) free(offset uint64) {
+	ind := sort.Search(len(f.spans), func(i int) bool {
+		return f.spans[i].offset >= offset
+	})
+
+	if ind == len(f.spans) || f.spans[ind].offset != offset || f.spans[ind].free {
+		panic(fmt.Errorf("memory at offset %d is not allocated", offset))
+	}
+
+	f.usedBytes -= f.spans[ind].size
+	f.allocs--
+	f.spans[ind].free = true
+
+	// merge with next
+	if ind < len(f.spans)-1 && f.spans[ind+1].free {
+		f.spans[ind].size += f.spans[ind+1].size
+		f.spans = append(f.spans[:ind+1], f.spans[ind+2:]...)
+	}
+
+	// merge with previous
+	if ind > 0 && f.spans[ind-1].free {
+		f.spans[ind-1].size += f.spans[ind].size
+		f.spans = append(f.spans[:ind], f.spans[ind+1:]...)
+	}
+}
+
+func (f *freeList) reset() {
+	f.spans = []span{{offset: 0, size: f.size, free: true}}
+	f.usedBytes = 0
+	f.allocs = 0
+}
+
+func (f *freeList) used() uint64 {
+	return f.usedBytes
+}
+
+func (f *freeList) count() int {
+	return f.allocs
+}
@@ -0,0 +1,185 @@
+package memory
+
+import (
+	"math/rand"
+	"testing"
+)
+
+func TestFreeList_Alloc(t *testing.T) {
+	tests := []struct {
+		name        string
+		size        uint64
+		granularity uint64
+		allocs      []testAlloc
+	}{
+		{
+			name: "packed",
+			size: 64,
+			allocs: []testAlloc{
+				{size: 16, align: 4, offset: 0, ok: true},
+				{size: 16, align: 4, offset: 16, ok: true},
+				{size: 32, align: 4, offset: 32, ok: true},
+				{size: 1, align: 1, ok: false},
+			},
+		},
+		{
+			name: "padding is reused",
+			size: 256,
+			allocs: []testAlloc{
+				{size: 8, align: 4, offset: 0, ok: true},
+				{size: 16, align: 64, offset: 64, ok: true},
+				{size: 32, align: 8, offset: 8, ok: true},
+				{size: 32, align: 8, offset: 80, ok: true},
+			},
+		},
+		{
+			name:        "granularity",
+			size:        4096,
+			granularity: 1024,
+			allocs: []testAlloc{
+				{size: 100, align: 4, kind: KindLinear, offset: 0, ok: true},
+				{size: 100, align: 16, kind: KindOptimal, offset: 1024, ok: true},
+				{size: 100, align: 4, kind: KindLinear, offset: 100, ok: true},
+				{size: 900, align: 4, kind: KindLinear, offset: 2048, ok: true},
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			algo := newFreeList(tt.size, tt.granularity)
+			assertAllocs(t, algo, tt.allocs)
+		})
+	}
+}
+
+func TestFreeList_GranularityBeforeUsed(t *testing.T) {
+	algo := newFreeList(4096, 1024)
+
+	first, _ := algo.alloc(1000, 4, KindLinear)
+	second, _ := algo.alloc(100, 4, KindLinear)
+	algo.free(first)
+
+	// optimal resource not fit before linear one in same page
+	offset, ok := algo.alloc(100, 4, KindOptimal)
+	if !ok {
+		t.Fatalf("alloc failed")
+	}
+
+	if offset < 1024 || onSamePage(second, 100, offset, 1024) {
+		t.Fatalf("optimal resource at %d share page with linear resource at %d", offset, second)
+	}
+}
+
+func TestFreeList_FreeMerge(t *testing.T) {
+	algo := newFreeList(64, 1)
+
+	offsets := make([]uint64, 0, 4)
+	for i := 0; i < 4; i++ {
+		offset, ok := algo.alloc(16, 4, KindLinear)
+		if !ok {
+			t.Fatalf("alloc %d failed", i)
+		}
+
+		offsets = append(offsets, offset)
+	}
+
+	// free in mixed order, all spans should be merged back
+	algo.free(offsets[1])
+	algo.free(offsets[3])
+	algo.free(offsets[2])
+
+	if offset, ok := algo.alloc(48, 4, KindLinear); !ok || offset != 16 {
+		t.Fatalf("alloc after merge = (%d, %v), want (16, true)", offset, ok)
+	}
+
+	algo.free(16)
+	algo.free(offsets[0])
+
+	if len(algo.spans) != 1 || !algo.spans[0].free || algo.spans[0].size != 64 {
+		t.Fatalf("spans not merged into one free span: %+v", algo.spans)
+	}
+
+	if algo.used() != 0 || algo.count() != 0 {
+		t.Fatalf("used = %d, count = %d, want 0", algo.used(), algo.count())
+	}
+}
+
+func TestFreeList_FreeUnknown(t *testing.T) {
+	algo := newFreeList(64, 1)
+	algo.alloc(16, 4, KindLinear)
+
+	defer func() {
+		if recover() == nil {
+			t.Fatalf("free of not allocated offset should panic")
+		}
+	}()
+
+	algo.free(8)
+}
+
+func TestFreeList_Random(t *testing.T) {
+	const size = 64 * 1024
+	const granularity = 256
+
+	algo := newFreeList(size, granularity)
+	rnd := rand.New(rand.NewSource(1))
+
+	type live struct {
+		offset, size uint64
+		kind         Kind
+	}
+
+	allocated := make([]live, 0)
+
+	for step := 0; step < 5000; step++ {
+		if len(allocated) > 0 && rnd.Intn(3) == 0 {
+			ind := rnd.Intn(len(allocated))
+			algo.free(allocated[ind].offset)
+			allocated = append(allocated[:ind], allocated[ind+1:]...)
+			continue
+		}
+
+		allocSize := uint64(rnd.Intn(2000) + 1)
+		align := uint64(1) << rnd.Intn(8)
+		kind := Kind(rnd.Intn(2))
+
+		offset, ok := algo.alloc(allocSize, align, kind)
+		if !ok {
+			continue
+		}
+
+		if offset%align != 0 {
+			t.Fatalf("offset %d is not aligned to %d", offset, align)
+		}
+
+		if offset+allocSize > size {
+			t.Fatalf("allocation [%d, %d) out of block", offset, offset+allocSize)
+		}
+
+		for _, other := range allocated {
+			if offset < other.offset+other.size && other.offset < offset+allocSize {
+				t.Fatalf("allocation [%d, %d) overlap [%d, %d)", offset, offset+allocSize, other.offset, other.offset+other.size)
+			}
+
+			if other.kind == kind {
+				continue
+			}
+
+			if onSamePage(other.offset, other.size, offset, granularity) || onSamePage(offset, allocSize, other.offset, granularity) {
+				t.Fatalf("allocation [%d, %d) share page with [%d, %d) of other kind", offset, offset+allocSize, other.offset, other.offset+other.size)
+			}
+		}
+
+		allocated = append(allocated, live{offset: offset, size: allocSize, kind: kind})
+	}
+
+	used := uint64(0)
+	for _, alloc := range allocated {
+		used += alloc.size
+	}
+
+	if algo.used() != used || algo.count() != len(allocated) {
+		t.Fatalf("used = %d, count = %d, want %d and %d", algo.used(), algo.count(), used, len(allocated))
+	}
+}
@@ -0,0 +1,64 @@
+package memory
+
+// linear is bump allocator, every allocation is placed right after
+// previous one, and single allocations can't be freed. All memory
+// is returned back with reset. Used for per-frame data, where block
+// is reset when GPU is done with frame
+type linear struct {
+	size        uint64
+	granularity uint64
+
+	cursor uint64
+	allocs int
+
+	// previous allocation, for granularity checks
+	lastOffset uint64
+	lastSize   uint64
+	lastKind   Kind
+}
+
+func newLinear(size, granularity uint64) *linear {
+	return &linear{
+		size:        size,
+		granularity: granularity,
+	}
+}
+
+func (l *linear) alloc(size, align uint64, kind Kind) (uint64, bool) {
+	if size == 0 {
+		return 0, false
+	}
+
+	offset := alignUp(l.cursor, align)
+
+	if l.allocs > 0 && kind != l.lastKind && onSamePage(l.lastOffset, l.lastSize, offset, l.granularity) {
+		offset = alignUp(offset, l.granularity)
+	}
+
+	if offset+size > l.size {
+		return 0, false
+	}
+
+	l.cursor = offset + size
+	l.allocs++
+	l.lastOffset, l.lastSize, l.lastKind = offset, size, kind
+
+	return offset, true
+}
+
+func (l *linear) free(uint64) {
+	// memory is returned only with reset
+}
+
+func (l *linear) reset() {
+	l.cursor = 0
+	l.allocs = 0
+}
+
+func (l *linear) used() uint64 {
+	return l.cursor
+}
+
+func (l *linear) count() int {
+	return l.allocs
+}
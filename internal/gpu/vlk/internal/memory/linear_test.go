@@ -0,0 +1,124 @@
+package memory
+
+import "testing"
+
+type testAlloc struct {
+	size   uint64
+	align  uint64
+	kind   Kind
+	offset uint64
+	ok     bool
+}
+
+func TestLinear_Alloc(t *testing.T) {
+	tests := []struct {
+		name        string
+		size        uint64
+		granularity uint64
+		allocs      []testAlloc
+	}{
+		{
+			name: "packed",
+			size: 256,
+			allocs: []testAlloc{
+				{size: 16, align: 4, offset: 0, ok: true},
+				{size: 16, align: 4, offset: 16, ok: true},
+				{size: 32, align: 4, offset: 32, ok: true},
+			},
+		},
+		{
+			name: "aligned",
+			size: 256,
+			allocs: []testAlloc{
+				{size: 10, align: 4, offset: 0, ok: true},
+				{size: 16, align: 64, offset: 64, ok: true},
+				{size: 1, align: 1, offset: 80, ok: true},
+				{size: 4, align: 4, offset: 84, ok: true},
+			},
+		},
+		{
+			name: "out of space",
+			size: 64,
+			allocs: []testAlloc{
+				{size: 48, align: 16, offset: 0, ok: true},
+				{size: 32, align: 16, ok: false},
+				{size: 16, align: 16, offset: 48, ok: true},
+				{size: 1, align: 1, ok: false},
+			},
+		},
+		{
+			name:        "granularity",
+			size:        4096,
+			granularity: 1024,
+			allocs: []testAlloc{
+				{size: 100, align: 4, kind: KindLinear, offset: 0, ok: true},
+				{size: 100, align: 4, kind: KindLinear, offset: 100, ok: true},
+				{size: 100, align: 16, kind: KindOptimal, offset: 1024, ok: true},
+				{size: 100, align: 16, kind: KindOptimal, offset: 1136, ok: true},
+				{size: 100, align: 4, kind: KindLinear, offset: 2048, ok: true},
+			},
+		},
+		{
+			name:        "granularity, different pages",
+			size:        4096,
+			granularity: 256,
+			allocs: []testAlloc{
+				{size: 256, align: 4, kind: KindLinear, offset: 0, ok: true},
+				{size: 100, align: 256, kind: KindOptimal, offset: 256, ok: true},
+			},
+		},
+		{
+			name: "zero size",
+			size: 64,
+			allocs: []testAlloc{
+				{size: 0, align: 4, ok: false},
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			algo := newLinear(tt.size, tt.granularity)
+			assertAllocs(t, algo, tt.allocs)
+		})
+	}
+}
+
+func TestLinear_Reset(t *testing.T) {
+	algo := newLinear(64, 1)
+
+	for i := 0; i < 4; i++ {
+		if _, ok := algo.alloc(16, 4, KindLinear); !ok {
+			t.Fatalf("alloc %d failed", i)
+		}
+	}
+
+	if algo.used() != 64 || algo.count() != 4 {
+		t.Fatalf("used = %d, count = %d, want 64 and 4", algo.used(), algo.count())
+	}
+
+	algo.reset()
+
+	if algo.used() != 0 || algo.count() != 0 {
+		t.Fatalf("after reset used = %d, count = %d, want 0", algo.used(), algo.count())
+	}
+
+	if offset, ok := algo.alloc(64, 4, KindOptimal); !ok || offset != 0 {
+		t.Fatalf("after reset alloc = (%d, %v), want (0, true)", offset, ok)
+	}
+}
+
+func assertAllocs(t *testing.T, algo algorithm, allocs []testAlloc) {
+	t.Helper()
+
+	for ind, want := range allocs {
+		offset, ok := algo.alloc(want.size, want.align, want.kind)
+		if ok != want.ok {
+			t.Fatalf("alloc #%d (size %d): ok = %v, want %v", ind, want.size, ok, want.ok)
+		}
+
+		if ok && offset != want.offset {
+			t.Fatalf("alloc #%d (size %d): offset = %d, want %d", ind, want.size, offset, want.offset)
+		}
+	}
+}
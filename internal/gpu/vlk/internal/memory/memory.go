@@ -0,0 +1,71 @@
+package memory
+
+// Kind of resource, placed into memory. Vulkan require that linear
+// and optimal resources, placed side by side in one VkDeviceMemory,
+// not share one "page" of bufferImageGranularity size
+type Kind uint8
+
+const (
+	KindLinear  Kind = iota // buffers and images with linear tiling
+	KindOptimal             // images with optimal tiling
+)
+
+type (
+	// algorithm place allocations inside one memory block.
+	// All offsets and sizes is in bytes, relative to block start
+	algorithm interface {
+		// alloc return offset of new allocation, or false,
+		// when there is no free space for it in block
+		alloc(size, align uint64, kind Kind) (uint64, bool)
+
+		// free return allocation at offset back to block
+		free(offset uint64)
+
+		// reset free all allocations at once
+		reset()
+
+		// used is total size of live allocations
+		// (linear also count alignment paddings)
+		used() uint64
+
+		// count is number of live allocations
+		count() int
+	}
+
+	// Stats is memory usage of allocator
+	Stats struct {
+		Blocks      int    // how many VkDeviceMemory is allocated
+		Allocations int    // how many resources placed into blocks
+		Reserved    uint64 // total size of blocks
+		Used        uint64 // size used by resources
+	}
+)
+
+func alignUp(value, align uint64) uint64 {
+	if align <= 1 {
+		return value
+	}
+
+	return (value + align - 1) / align * align
+}
+
+func alignDown(value, align uint64) uint64 {
+	if align <= 1 {
+		return value
+	}
+
+	return value / align * align
+}
+
+// onSamePage return true, when end of resource A and start
+// of resource B is in one page of granularity size
+func onSamePage(aOffset, aSize, bOffset, granularity uint64) bool {
+	if granularity <= 1 {
+		return false
+	}
+
+	aEndPage := alignDown(aOffset+aSize-1, granularity)
+	bStartPage := alignDown(bOffset, granularity)
+
+	return aEndPage == bStartPage
+}
@@ -0,0 +1,83 @@
+package must
+
+import (
+	"fmt"
+
+	"github.com/vulkan-go/vulkan"
+)
+
+// Error is failed vulkan call result.
+// Errors with same Result is equal in errors.Is, so
+// callers can check it with exported sentinel errors:
+//
+//	errors.Is(err, must.ErrOutOfDeviceMemory)
+type Error struct {
+	Result      vulkan.Result
+	Name        string
+	Description string
+	Where       string // file:line of failed call, can be empty
+}
+
+var (
+	ErrOutOfHostMemory        = NewError(vulkan.ErrorOutOfHostMemory)
+	ErrOutOfDeviceMemory      = NewError(vulkan.ErrorOutOfDeviceMemory)
+	ErrInitializationFailed   = NewError(vulkan.ErrorInitializationFailed)
+	ErrDeviceLost             = NewError(vulkan.ErrorDeviceLost)
+	ErrLayerNotPresent        = NewError(vulkan.ErrorLayerNotPresent)
+	ErrExtensionNotPresent    = NewError(vulkan.ErrorExtensionNotPresent)
+	ErrFeatureNotPresent      = NewError(vulkan.ErrorFeatureNotPresent)
+	ErrIncompatibleDriver     = NewError(vulkan.ErrorIncompatibleDriver)
+	ErrFormatNotSupported     = NewError(vulkan.ErrorFormatNotSupported)
+	ErrSurfaceLost            = NewError(vulkan.ErrorSurfaceLost)
+	ErrNativeWindowInUse      = NewError(vulkan.ErrorNativeWindowInUse)
+	ErrOutOfDate              = NewError(vulkan.ErrorOutOfDate)
+	ErrIncompatibleDisplay    = NewError(vulkan.ErrorIncompatibleDisplay)
+	ErrTooManyObjects         = NewError(vulkan.ErrorTooManyObjects)
+	ErrOutOfPoolMemory        = NewError(vulkan.ErrorOutOfPoolMemory)
+	ErrFragmentedPool         = NewError(vulkan.ErrorFragmentedPool)
+	ErrMemoryMapFailed        = NewError(vulkan.ErrorMemoryMapFailed)
+	ErrInvalidExternalHandle  = NewError(vulkan.ErrorInvalidExternalHandle)
+	ErrFragmentation          = NewError(vulkan.ErrorFragmentation)
+	ErrNotPermitted           = NewError(vulkan.ErrorNotPermitted)
+	ErrValidationFailed       = NewError(vulkan.ErrorValidationFailed)
+	ErrInvalidShaderNv        = NewError(vulkan.ErrorInvalidShaderNv)
+	ErrInvalidDrmFormatLayout = NewError(vulkan.ErrorInvalidDrmFormatModifierPlaneLayout)
+)
+
+// NewError create error from vulkan result code,
+// name and description is taken from codeNameReferences
+func NewError(vkResult vulkan.Result) *Error {
+	err := &Error{
+		Result: vkResult,
+		Name:   "unknown",
+	}
+
+	if ref, ok := codeNameReferences[vkResult]; ok {
+		err.Name, err.Description = ref[0], ref[1]
+	}
+
+	return err
+}
+
+func (e *Error) Error() string {
+	msg := fmt.Sprintf("vk: %s (%d)", e.Name, e.Result)
+
+	if e.Description != "" {
+		msg += ": " + e.Description
+	}
+
+	if e.Where != "" {
+		msg += ", at " + e.Where
+	}
+
+	return msg
+}
+
+func (e *Error) Is(target error) bool {
+	t, ok := target.(*Error)
+	if !ok {
+		return false
+	}
+
+	return t.Result == e.Result
+}
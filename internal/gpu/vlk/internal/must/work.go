@@ -2,66 +2,91 @@ package must
 
 import (
 	"fmt"
-	"log"
+	"log/slog"
 	"runtime"
 
 	"github.com/vulkan-go/vulkan"
 )
 
-// Work will panic when vkResult is not success
+// Work will panic with *Error when vkResult is not success.
+// Panic is converted back to error on public API
+// boundary (vgl.NewRender, vgl.Render.Err, etc..)
 func Work(vkResult vulkan.Result) {
 	if vkResult == vulkan.Success {
 		return
 	}
 
-	panic(asGoError(vkResult, false))
+	panic(asGoError(vkResult))
+}
+
+// Check will return *Error when vkResult is not success
+func Check(vkResult vulkan.Result) error {
+	if vkResult == vulkan.Success {
+		return nil
+	}
+
+	return asGoError(vkResult)
 }
 
 // NotCare will do nothing when vkResult is success
 // and log error, when is not.
 // also return true when vkResult is success
-func NotCare(vkResult vulkan.Result) bool {
+func NotCare(logger *slog.Logger, vkResult vulkan.Result) bool {
 	if vkResult == vulkan.Success {
 		return true
 	}
 
-	log.Println(asGoError(vkResult, true).Error())
+	err := asGoError(vkResult)
+	logger.Error("vulkan call failed",
+		slog.Int("code", int(err.Result)),
+		slog.String("err", err.Name),
+		slog.String("at", err.Where),
+	)
+
 	return false
 }
 
-func asGoError(vkResult vulkan.Result, short bool) error {
-	errorName := "unknown"
-	description := ""
+// Frame is for per-frame calls (fences, submit, present). It return
+// true when vkResult is success, log and return false, when frame
+// can be just skipped (timeout, swapchain out of date), and panic
+// with *Error on fatal results (device lost, out of memory, etc..),
+// see Recoverable
+func Frame(logger *slog.Logger, vkResult vulkan.Result) bool {
+	if vkResult == vulkan.Success {
+		return true
+	}
 
-	if ref, ok := codeNameReferences[vkResult]; ok {
-		errorName, description = ref[0], ref[1]
+	err := asGoError(vkResult)
+	if !Recoverable(vkResult) {
+		panic(err)
 	}
 
-	where := "unknown"
-	if _, file, line, ok := runtime.Caller(2); ok {
-		where = fmt.Sprintf("%s:%d", file, line)
+	logger.Warn("frame skipped",
+		slog.Int("code", int(err.Result)),
+		slog.String("err", err.Name),
+		slog.String("at", err.Where),
+	)
+
+	return false
+}
+
+// Recoverable report that vkResult is not error, or error, that
+// fixed by skipping frame or swapchain rebuild
+func Recoverable(vkResult vulkan.Result) bool {
+	switch vkResult {
+	case vulkan.Success, vulkan.Suboptimal, vulkan.ErrorOutOfDate, vulkan.Timeout, vulkan.NotReady:
+		return true
+	default:
+		return false
 	}
+}
+
+func asGoError(vkResult vulkan.Result) *Error {
+	err := NewError(vkResult)
 
-	if short {
-		return fmt.Errorf("vk: Err: %d (%s), at %s",
-			vkResult,
-			errorName,
-			where,
-		)
+	if _, file, line, ok := runtime.Caller(2); ok {
+		err.Where = fmt.Sprintf("%s:%d", file, line)
 	}
 
-	return fmt.Errorf(
-		"\n----------- VK Error -----------\n"+
-			"  code: %d\n"+
-			"   err: %s\n"+
-			"    at: %s\n"+
-			"reason: %s\n"+
-			"  more: %s\n"+
-			"----------- ^ -----------\n\n",
-		vkResult,
-		errorName,
-		where,
-		description,
-		errorCodesUrl,
-	)
+	return err
 }
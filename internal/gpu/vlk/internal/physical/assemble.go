@@ -8,14 +8,10 @@ import (
 	"github.com/go-glx/vgl/internal/gpu/vlk/internal/vkconv"
 )
 
-func (d *Device) assembleGPU(pd vulkan.PhysicalDevice) *GPU {
-	var props vulkan.PhysicalDeviceProperties
-	vulkan.GetPhysicalDeviceProperties(pd, &props)
-	props.Deref()
-
-	var features vulkan.PhysicalDeviceFeatures
-	vulkan.GetPhysicalDeviceFeatures(pd, &features)
-	features.Deref()
+func (d *Device) assembleGPU(index int, pd vulkan.PhysicalDevice) *GPU {
+	props := assembleProps(pd)
+	features := assembleFeatures(pd)
+	memory := assembleMemory(pd)
 
 	vkExtList := make([]string, 0, len(def.RequiredDeviceExtensions))
 	for _, extName := range def.RequiredDeviceExtensions {
@@ -26,6 +22,8 @@ func (d *Device) assembleGPU(pd vulkan.PhysicalDevice) *GPU {
 		Ref:                pd,
 		Props:              props,
 		Features:           features,
+		Memory:             memory,
+		Info:               newInfo(index, props, features, memory),
 		Families:           d.assembleFamilies(pd),
 		Extensions:         d.assembleExtensions(pd),
 		SurfaceProps:       d.assembleSurfaceProps(pd),
@@ -33,6 +31,30 @@ func (d *Device) assembleGPU(pd vulkan.PhysicalDevice) *GPU {
 	}
 }
 
+func assembleProps(pd vulkan.PhysicalDevice) vulkan.PhysicalDeviceProperties {
+	var props vulkan.PhysicalDeviceProperties
+	vulkan.GetPhysicalDeviceProperties(pd, &props)
+	props.Deref()
+
+	return props
+}
+
+func assembleFeatures(pd vulkan.PhysicalDevice) vulkan.PhysicalDeviceFeatures {
+	var features vulkan.PhysicalDeviceFeatures
+	vulkan.GetPhysicalDeviceFeatures(pd, &features)
+	features.Deref()
+
+	return features
+}
+
+func assembleMemory(pd vulkan.PhysicalDevice) vulkan.PhysicalDeviceMemoryProperties {
+	var memory vulkan.PhysicalDeviceMemoryProperties
+	vulkan.GetPhysicalDeviceMemoryProperties(pd, &memory)
+	memory.Deref()
+
+	return memory
+}
+
 func (d *Device) assembleFamilies(device vulkan.PhysicalDevice) Families {
 	count := uint32(0)
 	vulkan.GetPhysicalDeviceQueueFamilyProperties(device, &count, nil)
@@ -52,6 +74,7 @@ func (d *Device) assembleFamilies(device vulkan.PhysicalDevice) Families {
 		properties.Deref()
 		if properties.QueueFlags&vulkan.QueueFlags(vulkan.QueueGraphicsBit) != 0 {
 			result.GraphicsFamilyId = uint32(familyId)
+			result.GraphicsTimestampBits = properties.TimestampValidBits
 			result.supportGraphics = true
 		}
 
@@ -91,6 +114,7 @@ func (d *Device) assembleSurfaceProps(pd vulkan.PhysicalDevice) SurfaceProps {
 		capabilities: d.assembleSurfacePropsCapabilities(pd),
 		formats:      d.assembleSurfacePropsFormats(pd),
 		presentModes: d.assembleSurfacePropsPresentModes(pd),
+		hdr:          d.inst.HasExtension(def.SwapchainColorspaceExtension),
 	}
 }
 
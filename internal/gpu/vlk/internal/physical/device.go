@@ -1,19 +1,23 @@
 package physical
 
 import (
+	"log/slog"
+
 	"github.com/go-glx/vgl/internal/gpu/vlk/internal/instance"
 	"github.com/go-glx/vgl/internal/gpu/vlk/internal/surface"
 )
 
 type Device struct {
-	inst    *instance.Instance
-	surface *surface.Surface
+	logger   *slog.Logger
+	inst     *instance.Instance
+	surface  *surface.Surface
+	selector Selector
 
 	primaryGPU *GPU
 }
 
-func NewDevice(inst *instance.Instance, surface *surface.Surface) *Device {
-	dev := &Device{inst: inst, surface: surface}
+func NewDevice(logger *slog.Logger, inst *instance.Instance, surface *surface.Surface, selector Selector) *Device {
+	dev := &Device{logger: logger, inst: inst, surface: surface, selector: selector}
 	dev.primaryGPU = dev.pickPrimaryGPU()
 
 	return dev
@@ -1,7 +1,7 @@
 package physical
 
 import (
-	"log"
+	"log/slog"
 	"strings"
 
 	"github.com/vulkan-go/vulkan"
@@ -15,6 +15,8 @@ type (
 		Ref                vulkan.PhysicalDevice
 		Props              vulkan.PhysicalDeviceProperties
 		Features           vulkan.PhysicalDeviceFeatures
+		Memory             vulkan.PhysicalDeviceMemoryProperties
+		Info               Info
 		Extensions         []vulkan.ExtensionProperties
 		Families           Families
 		SurfaceProps       SurfaceProps
@@ -22,7 +24,7 @@ type (
 	}
 )
 
-func (pd *GPU) isSupportAllRequiredExtensions() bool {
+func (pd *GPU) isSupportAllRequiredExtensions(logger *slog.Logger) bool {
 	supportedExt := make(map[string]any)
 
 	for _, extension := range pd.Extensions {
@@ -42,10 +44,9 @@ func (pd *GPU) isSupportAllRequiredExtensions() bool {
 	}
 
 	if len(notSupported) > 0 {
-		log.Printf(
-			"vk: GPU '%s' not support all required extensions: [%s]\n",
-			vkconv.VarcharAsString(pd.Props.DeviceName),
-			strings.Join(notSupported, ", "),
+		logger.Debug("GPU not support all required extensions",
+			slog.String("gpu", vkconv.VarcharAsString(pd.Props.DeviceName)),
+			slog.String("extensions", strings.Join(notSupported, ", ")),
 		)
 
 		return false
@@ -4,8 +4,13 @@ type (
 	Families struct {
 		GraphicsFamilyId uint32
 		PresentFamilyId  uint32
-		supportGraphics  bool
-		supportPresent   bool
+
+		// timestampValidBits of graphics family, zero
+		// when queue not support timestamp queries
+		GraphicsTimestampBits uint32
+
+		supportGraphics bool
+		supportPresent  bool
 	}
 )
 
@@ -1,7 +1,6 @@
 package physical
 
 import (
-	"fmt"
 	"math"
 
 	"github.com/vulkan-go/vulkan"
@@ -16,6 +15,7 @@ type (
 		formats        []vulkan.SurfaceFormat
 		presentModes   []vulkan.PresentMode
 		surfaceSupport bool
+		hdr            bool // HDR color spaces is enabled in instance
 	}
 )
 
@@ -40,63 +40,58 @@ func (ds *SurfaceProps) ConcurrentBuffersCount() uint32 {
 	)
 }
 
-func (ds *SurfaceProps) RichColorSpaceFormat() *vulkan.SurfaceFormat {
-	for _, surfaceFormat := range ds.formats {
-		if surfaceFormat.Format != def.SurfaceFormat {
-			continue
-		}
+// SurfaceFormat return first supported format from
+// def.HDRSurfaceFormats (when HDR enabled) or def.SurfaceFormats
+// ranked list, or false when GPU not support any of them
+func (ds *SurfaceProps) SurfaceFormat() (def.SurfaceFormat, bool) {
+	ranked := def.SurfaceFormats
+	if ds.hdr {
+		ranked = append(append([]def.SurfaceFormat{}, def.HDRSurfaceFormats...), def.SurfaceFormats...)
+	}
 
-		if surfaceFormat.ColorSpace != def.SurfaceColorSpace {
-			continue
+	for _, want := range ranked {
+		for _, surfaceFormat := range ds.formats {
+			if surfaceFormat.Format == want.Format && surfaceFormat.ColorSpace == want.ColorSpace {
+				return want, true
+			}
 		}
-
-		return &surfaceFormat
 	}
 
-	return nil
+	return def.SurfaceFormat{}, false
 }
 
-func (ds *SurfaceProps) BestPresentMode(mobileFriendly bool) vulkan.PresentMode {
-	for _, mode := range ds.presentModes {
-		if mobileFriendly && mode == vulkan.PresentModeFifo {
-			// 1# [R]       [D.......][R]
-			// 2# [D.......][R]       [D.......]
-			//    |         |         |
-			//   frm1      frm 2     frm 3
-			//
-			// Fifo is low consumption rendering, friendly for
-			// mobile devices. When we render (R) some buffer, it will
-			// stay all time before displayed (D) on screen.
-			//
-			// + vsync
-			// + good for mobiles (low power consumption)
-			// + always supported
-			// - high latency
-			return mode
-		}
+func (ds *SurfaceProps) hasSurfaceFormat() bool {
+	_, ok := ds.SurfaceFormat()
+	return ok
+}
 
-		if mode == vulkan.PresentModeMailbox {
-			// 1# [R][R][R] [D] ///// [R]
-			// 2# [D] ///// [R][R][R] [D]
-			//    |         |         |
-			//   frm1      frm 2     frm 3
-			//
-			// Mailbox is high power consumption mode, that will
-			// heat GPU to max, and re-render (R) all frames when
-			// GPU has free time (idle). It`s a lot of work, that
-			// will be ignored. For example, we render (R) 3 buffers per frame
-			// here, and display (D) only last of them.
-			//
-			// + low latency
-			// - not always supported on all GPU's
-			// - high power consumption
-			return mode
+// PresentMode return first supported mode from preferred list.
+// FIFO is used as fallback, because it's guaranteed to be
+// supported by vulkan spec.
+//
+// Fifo (vsync) is low consumption rendering, friendly for
+// mobile devices. When we render (R) some buffer, it will
+// stay all time before displayed (D) on screen:
+//
+//	1# [R]       [D.......][R]
+//	2# [D.......][R]       [D.......]
+//
+// Mailbox is high power consumption mode, that will
+// re-render (R) frames when GPU has free time (idle), and
+// display (D) only last of them:
+//
+//	1# [R][R][R] [D] ///// [R]
+//	2# [D] ///// [R][R][R] [D]
+func (ds *SurfaceProps) PresentMode(preferred []vulkan.PresentMode) vulkan.PresentMode {
+	for _, want := range preferred {
+		for _, mode := range ds.presentModes {
+			if mode == want {
+				return mode
+			}
 		}
-
-		return mode
 	}
 
-	panic(fmt.Errorf("GPU not support any present mode"))
+	return vulkan.PresentModeFifo
 }
 
 func (ds *SurfaceProps) ChooseSwapExtent(width, height uint32) vulkan.Extent2D {
@@ -0,0 +1,201 @@
+package physical
+
+import (
+	"fmt"
+	"runtime"
+
+	"github.com/vulkan-go/vulkan"
+
+	"github.com/go-glx/vgl/internal/gpu/vlk/internal/instance"
+	"github.com/go-glx/vgl/internal/gpu/vlk/internal/vkconv"
+)
+
+type (
+	// Info is human-readable GPU description,
+	// not depend on window surface
+	Info struct {
+		Index         int          `json:"index"` // enumeration index, can be used in GPU selector
+		Name          string       `json:"name"`  // "NVIDIA GeForce RTX 3060 Laptop GPU"
+		Type          string       `json:"type"`  // discrete, integrated, virtual, cpu, other
+		VendorID      uint32       `json:"vendor_id"`
+		Vendor        string       `json:"vendor"` // "NVIDIA", "AMD", "Intel", etc.. or hex ID for unknown vendors
+		DeviceID      uint32       `json:"device_id"`
+		DriverVersion string       `json:"driver_version"`
+		APIVersion    string       `json:"api_version"`
+		MemoryHeaps   []MemoryHeap `json:"memory_heaps"`
+		Features      []string     `json:"features"` // enabled optional features (geometryShader, samplerAnisotropy, etc..)
+		Limits        Limits       `json:"limits"`
+	}
+
+	MemoryHeap struct {
+		Size        uint64 `json:"size"`
+		DeviceLocal bool   `json:"device_local"` // VRAM (or shared memory on integrated GPUs)
+	}
+
+	Limits struct {
+		MaxImageDimension2D    uint32 `json:"max_image_dimension_2d"`
+		MaxPushConstantsSize   uint32 `json:"max_push_constants_size"`
+		MaxBoundDescriptorSets uint32 `json:"max_bound_descriptor_sets"`
+		MaxMemoryAllocations   uint32 `json:"max_memory_allocations"`
+		MaxViewports           uint32 `json:"max_viewports"`
+		BufferImageGranularity uint64 `json:"buffer_image_granularity"`
+		MinUniformBufferAlign  uint64 `json:"min_uniform_buffer_align"`
+	}
+)
+
+const (
+	TypeDiscrete   = "discrete"
+	TypeIntegrated = "integrated"
+	TypeVirtual    = "virtual"
+	TypeCPU        = "cpu"
+	TypeOther      = "other"
+)
+
+var vendorNames = map[uint32]string{
+	0x1002:  "AMD",
+	0x1010:  "ImgTec",
+	0x106B:  "Apple",
+	0x10DE:  "NVIDIA",
+	0x13B5:  "ARM",
+	0x5143:  "Qualcomm",
+	0x8086:  "Intel",
+	0x10005: "Mesa",
+}
+
+var deviceTypes = map[vulkan.PhysicalDeviceType]string{
+	vulkan.PhysicalDeviceTypeDiscreteGpu:   TypeDiscrete,
+	vulkan.PhysicalDeviceTypeIntegratedGpu: TypeIntegrated,
+	vulkan.PhysicalDeviceTypeVirtualGpu:    TypeVirtual,
+	vulkan.PhysicalDeviceTypeCpu:           TypeCPU,
+}
+
+// ListInfo return description of all GPU's available in
+// instance, in enumeration order. Window surface is not required
+func ListInfo(inst *instance.Instance) []Info {
+	list := make([]Info, 0)
+
+	for index, pd := range enumerate(inst.Ref()) {
+		list = append(list, newInfo(index, assembleProps(pd), assembleFeatures(pd), assembleMemory(pd)))
+	}
+
+	return list
+}
+
+func newInfo(
+	index int,
+	props vulkan.PhysicalDeviceProperties,
+	features vulkan.PhysicalDeviceFeatures,
+	memory vulkan.PhysicalDeviceMemoryProperties,
+) Info {
+	limits := props.Limits
+	limits.Deref()
+
+	heaps := make([]MemoryHeap, 0, memory.MemoryHeapCount)
+	for i := uint32(0); i < memory.MemoryHeapCount; i++ {
+		heap := memory.MemoryHeaps[i]
+		heap.Deref()
+
+		heaps = append(heaps, MemoryHeap{
+			Size:        uint64(heap.Size),
+			DeviceLocal: heap.Flags&vulkan.MemoryHeapFlags(vulkan.MemoryHeapDeviceLocalBit) != 0,
+		})
+	}
+
+	return Info{
+		Index:         index,
+		Name:          vkconv.VarcharAsString(props.DeviceName),
+		Type:          deviceTypeName(props.DeviceType),
+		VendorID:      props.VendorID,
+		Vendor:        vendorName(props.VendorID),
+		DeviceID:      props.DeviceID,
+		DriverVersion: driverVersion(props.VendorID, props.DriverVersion),
+		APIVersion:    apiVersion(props.ApiVersion),
+		MemoryHeaps:   heaps,
+		Features:      featureNames(features),
+		Limits: Limits{
+			MaxImageDimension2D:    limits.MaxImageDimension2D,
+			MaxPushConstantsSize:   limits.MaxPushConstantsSize,
+			MaxBoundDescriptorSets: limits.MaxBoundDescriptorSets,
+			MaxMemoryAllocations:   limits.MaxMemoryAllocationCount,
+			MaxViewports:           limits.MaxViewports,
+			BufferImageGranularity: uint64(limits.BufferImageGranularity),
+			MinUniformBufferAlign:  uint64(limits.MinUniformBufferOffsetAlignment),
+		},
+	}
+}
+
+// DeviceLocalMemory return total size of VRAM heaps
+func (i *Info) DeviceLocalMemory() uint64 {
+	total := uint64(0)
+
+	for _, heap := range i.MemoryHeaps {
+		if heap.DeviceLocal {
+			total += heap.Size
+		}
+	}
+
+	return total
+}
+
+func deviceTypeName(deviceType vulkan.PhysicalDeviceType) string {
+	if name, ok := deviceTypes[deviceType]; ok {
+		return name
+	}
+
+	return TypeOther
+}
+
+func vendorName(vendorID uint32) string {
+	if name, ok := vendorNames[vendorID]; ok {
+		return name
+	}
+
+	return fmt.Sprintf("0x%04X", vendorID)
+}
+
+func apiVersion(v uint32) string {
+	return fmt.Sprintf("%d.%d.%d", v>>22, (v>>12)&0x3ff, v&0xfff)
+}
+
+// driverVersion decode vendor specific driver version
+func driverVersion(vendorID uint32, v uint32) string {
+	switch {
+	case vendorID == 0x10DE:
+		return fmt.Sprintf("%d.%d.%d.%d", v>>22, (v>>14)&0xff, (v>>6)&0xff, v&0x3f)
+	case vendorID == 0x8086 && runtime.GOOS == "windows":
+		return fmt.Sprintf("%d.%d", v>>14, v&0x3fff)
+	default:
+		return apiVersion(v)
+	}
+}
+
+func featureNames(f vulkan.PhysicalDeviceFeatures) []string {
+	known := []struct {
+		name      string
+		supported vulkan.Bool32
+	}{
+		{"geometryShader", f.GeometryShader},
+		{"tessellationShader", f.TessellationShader},
+		{"multiDrawIndirect", f.MultiDrawIndirect},
+		{"fillModeNonSolid", f.FillModeNonSolid},
+		{"wideLines", f.WideLines},
+		{"largePoints", f.LargePoints},
+		{"multiViewport", f.MultiViewport},
+		{"samplerAnisotropy", f.SamplerAnisotropy},
+		{"textureCompressionBC", f.TextureCompressionBC},
+		{"textureCompressionETC2", f.TextureCompressionETC2},
+		{"textureCompressionASTC_LDR", f.TextureCompressionASTC_LDR},
+		{"shaderFloat64", f.ShaderFloat64},
+		{"shaderInt64", f.ShaderInt64},
+		{"shaderInt16", f.ShaderInt16},
+	}
+
+	names := make([]string, 0, len(known))
+	for _, feature := range known {
+		if feature.supported == vulkan.True {
+			names = append(names, feature.name)
+		}
+	}
+
+	return names
+}
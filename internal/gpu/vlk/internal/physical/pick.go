@@ -1,59 +1,154 @@
 package physical
 
 import (
-	"fmt"
-	"log"
+	"errors"
+	"log/slog"
+	"strings"
 
 	"github.com/vulkan-go/vulkan"
 
 	"github.com/go-glx/vgl/internal/gpu/vlk/internal/must"
-	"github.com/go-glx/vgl/internal/gpu/vlk/internal/vkconv"
 )
 
+var ErrNoSuitableGPU = errors.New("not found suitable vulkan GPU for rendering")
+
+type (
+	// Selector override automatic GPU selection (by score).
+	// Only one of rules is used, in order: Index, Name, Types.
+	// Zero value is AnyGPU
+	Selector struct {
+		ByIndex bool     // select by Index
+		Index   int      // enumeration index (see Info.Index)
+		Name    string   // case-insensitive substring of GPU name
+		Types   []string // ordered preference of GPU types (TypeDiscrete, etc..)
+	}
+
+	candidate struct {
+		gpu   *GPU
+		info  Info
+		score int
+	}
+)
+
+// AnyGPU is selector without any rules,
+// GPU will be selected by best score
+var AnyGPU = Selector{}
+
 func (d *Device) pickPrimaryGPU() *GPU {
-	bestScore := -1
-	var bestDevice *GPU
+	candidates := make([]candidate, 0)
+
+	for index, ref := range enumerate(d.inst.Ref()) {
+		pd := d.assembleGPU(index, ref)
 
-	for _, pd := range d.listDevices() {
 		score := d.score(pd)
 		if score < 0 {
 			// device is not suitable at all
 			continue
 		}
 
-		log.Printf("vk: GPU '%s' is suitable for use, score = %d\n",
-			vkconv.VarcharAsString(pd.Props.DeviceName),
-			score,
+		d.logger.Debug("GPU is suitable for use",
+			slog.String("gpu", pd.Info.Name),
+			slog.Int("index", index),
+			slog.Int("score", score),
+		)
+
+		candidates = append(candidates, candidate{gpu: pd, info: pd.Info, score: score})
+	}
+
+	if len(candidates) == 0 {
+		panic(ErrNoSuitableGPU)
+	}
+
+	best := selectCandidate(d.logger, candidates, d.selector)
+
+	d.logger.Info("using GPU",
+		slog.String("gpu", best.info.Name),
+		slog.String("type", best.info.Type),
+		slog.String("driver", best.info.DriverVersion),
+	)
+
+	return best.gpu
+}
+
+// selectCandidate return candidate matched selector, or candidate
+// with best score, when selector is empty or nothing matched
+func selectCandidate(logger *slog.Logger, candidates []candidate, sel Selector) candidate {
+	var matched []candidate
+	var rule string
+
+	switch {
+	case sel.ByIndex:
+		rule = "index"
+		matched = filterCandidates(candidates, func(c candidate) bool {
+			return c.info.Index == sel.Index
+		})
+	case sel.Name != "":
+		rule = "name"
+		matched = filterCandidates(candidates, func(c candidate) bool {
+			return strings.Contains(strings.ToLower(c.info.Name), strings.ToLower(sel.Name))
+		})
+	case len(sel.Types) > 0:
+		rule = "type"
+		for _, gpuType := range sel.Types {
+			matched = filterCandidates(candidates, func(c candidate) bool {
+				return c.info.Type == gpuType
+			})
+
+			if len(matched) > 0 {
+				break
+			}
+		}
+	default:
+		return bestCandidate(candidates)
+	}
+
+	if len(matched) == 0 {
+		logger.Warn("no suitable GPU matched selector, fallback to best score",
+			slog.String("rule", rule),
+			slog.Int("index", sel.Index),
+			slog.String("name", sel.Name),
+			slog.String("types", strings.Join(sel.Types, ",")),
 		)
 
-		if score > bestScore {
-			bestScore = score
-			bestDevice = pd
+		return bestCandidate(candidates)
+	}
+
+	return bestCandidate(matched)
+}
+
+func filterCandidates(candidates []candidate, match func(c candidate) bool) []candidate {
+	matched := make([]candidate, 0, len(candidates))
+
+	for _, c := range candidates {
+		if match(c) {
+			matched = append(matched, c)
 		}
 	}
 
-	if bestDevice == nil {
-		panic(fmt.Errorf("not found suitable vulkan GPU for rendering"))
+	return matched
+}
+
+func bestCandidate(candidates []candidate) candidate {
+	best := candidates[0]
+
+	for _, c := range candidates[1:] {
+		if c.score > best.score {
+			best = c
+		}
 	}
 
-	log.Printf("vk: using GPU: %s\n", vkconv.VarcharAsString(bestDevice.Props.DeviceName))
-	return bestDevice
+	return best
 }
 
-func (d *Device) listDevices() []*GPU {
+func enumerate(inst vulkan.Instance) []vulkan.PhysicalDevice {
 	count := uint32(0)
-	must.Work(vulkan.EnumeratePhysicalDevices(d.inst.Ref(), &count, nil))
+	must.Work(vulkan.EnumeratePhysicalDevices(inst, &count, nil))
 	if count <= 0 {
 		return nil
 	}
 
 	physicalDevices := make([]vulkan.PhysicalDevice, count)
-	must.Work(vulkan.EnumeratePhysicalDevices(d.inst.Ref(), &count, physicalDevices))
-
-	result := make([]*GPU, 0, len(physicalDevices))
-	for _, pd := range physicalDevices {
-		result = append(result, d.assembleGPU(pd))
-	}
+	must.Work(vulkan.EnumeratePhysicalDevices(inst, &count, physicalDevices))
 
-	return result
+	return physicalDevices
 }
@@ -0,0 +1,67 @@
+package physical
+
+import (
+	"io"
+	"log/slog"
+	"testing"
+)
+
+const gb = 1024 * 1024 * 1024
+
+func testCandidates() []candidate {
+	infos := []Info{
+		{Index: 0, Name: "Intel(R) UHD Graphics 630", Type: TypeIntegrated, MemoryHeaps: []MemoryHeap{{Size: 8 * gb, DeviceLocal: true}}},
+		{Index: 1, Name: "NVIDIA GeForce GTX 1650", Type: TypeDiscrete, MemoryHeaps: []MemoryHeap{{Size: 4 * gb, DeviceLocal: true}}},
+		{Index: 2, Name: "NVIDIA GeForce RTX 3060", Type: TypeDiscrete, MemoryHeaps: []MemoryHeap{{Size: 12 * gb, DeviceLocal: true}}},
+		{Index: 3, Name: "llvmpipe (LLVM 15.0.7, 256 bits)", Type: TypeCPU},
+	}
+
+	candidates := make([]candidate, 0, len(infos))
+	for _, info := range infos {
+		candidates = append(candidates, candidate{info: info, score: scoreInfo(info)})
+	}
+
+	return candidates
+}
+
+func TestSelectCandidate(t *testing.T) {
+	tests := []struct {
+		name string
+		sel  Selector
+		want int
+	}{
+		{name: "best score", sel: AnyGPU, want: 2},
+		{name: "by index", sel: Selector{ByIndex: true, Index: 1}, want: 1},
+		{name: "by first index", sel: Selector{ByIndex: true, Index: 0}, want: 0},
+		{name: "zero value", sel: Selector{}, want: 2},
+		{name: "by name", sel: Selector{Name: "uhd"}, want: 0},
+		{name: "by type", sel: Selector{Types: []string{TypeIntegrated}}, want: 0},
+		{name: "by type fallback", sel: Selector{Types: []string{TypeVirtual, TypeCPU}}, want: 3},
+		{name: "not matched", sel: Selector{ByIndex: true, Index: 10}, want: 2},
+	}
+
+	logger := slog.New(slog.NewTextHandler(io.Discard, nil))
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := selectCandidate(logger, testCandidates(), tt.sel)
+			if got.info.Index != tt.want {
+				t.Errorf("selected GPU %d (%s), want %d", got.info.Index, got.info.Name, tt.want)
+			}
+		})
+	}
+}
+
+func TestScoreInfo(t *testing.T) {
+	small := Info{Type: TypeDiscrete, MemoryHeaps: []MemoryHeap{{Size: 2 * gb, DeviceLocal: true}}}
+	big := Info{Type: TypeDiscrete, MemoryHeaps: []MemoryHeap{{Size: 16 * gb, DeviceLocal: true}}}
+	shared := Info{Type: TypeIntegrated, MemoryHeaps: []MemoryHeap{{Size: 64 * gb, DeviceLocal: true}}}
+
+	if scoreInfo(big) <= scoreInfo(small) {
+		t.Errorf("GPU with more VRAM should have better score")
+	}
+
+	if scoreInfo(shared) >= scoreInfo(small) {
+		t.Errorf("discrete GPU should be preferred over integrated with big shared memory")
+	}
+}
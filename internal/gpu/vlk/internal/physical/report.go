@@ -0,0 +1,106 @@
+package physical
+
+import (
+	"log/slog"
+
+	"github.com/go-glx/vgl/internal/gpu/vlk/internal/instance"
+	"github.com/go-glx/vgl/internal/gpu/vlk/internal/surface"
+	"github.com/go-glx/vgl/internal/gpu/vlk/internal/vkconv"
+)
+
+type (
+	// Report is full description of GPU for window surface,
+	// with all checks, that used in GPU selection
+	Report struct {
+		Info
+		Suitable       bool            `json:"suitable"`
+		Problems       []string        `json:"problems"` // why GPU is not suitable
+		Score          int             `json:"score"`
+		Selected       bool            `json:"selected"` // GPU will be used for rendering
+		Families       FamiliesReport  `json:"families"`
+		Extensions     []string        `json:"extensions"`
+		SurfaceFormats []SurfaceFormat `json:"surface_formats"`
+		PresentModes   []string        `json:"present_modes"`
+		MinImageCount  uint32          `json:"min_image_count"`
+		MaxImageCount  uint32          `json:"max_image_count"` // 0 is unlimited
+	}
+
+	FamiliesReport struct {
+		Graphics         bool   `json:"graphics"`
+		Present          bool   `json:"present"`
+		GraphicsFamilyID uint32 `json:"graphics_family_id"`
+		PresentFamilyID  uint32 `json:"present_family_id"`
+	}
+
+	SurfaceFormat struct {
+		Format     string `json:"format"`
+		ColorSpace string `json:"color_space"`
+	}
+)
+
+// NewReport assemble all GPU's, like it done in NewDevice,
+// but without picking and failing when nothing is suitable
+func NewReport(logger *slog.Logger, inst *instance.Instance, surface *surface.Surface, selector Selector) []Report {
+	dev := &Device{logger: logger, inst: inst, surface: surface, selector: selector}
+
+	reports := make([]Report, 0)
+	candidates := make([]candidate, 0)
+
+	for index, ref := range enumerate(inst.Ref()) {
+		pd := dev.assembleGPU(index, ref)
+		report := newReport(pd, dev.problems(pd))
+
+		if report.Suitable {
+			report.Score = scoreInfo(pd.Info)
+			candidates = append(candidates, candidate{gpu: pd, info: pd.Info, score: report.Score})
+		}
+
+		reports = append(reports, report)
+	}
+
+	if len(candidates) > 0 {
+		selected := selectCandidate(logger, candidates, selector)
+		reports[selected.info.Index].Selected = true
+	}
+
+	return reports
+}
+
+func newReport(pd *GPU, problems []string) Report {
+	extensions := make([]string, 0, len(pd.Extensions))
+	for _, ext := range pd.Extensions {
+		extensions = append(extensions, vkconv.VarcharAsString(ext.ExtensionName))
+	}
+
+	formats := make([]SurfaceFormat, 0, len(pd.SurfaceProps.formats))
+	for _, format := range pd.SurfaceProps.formats {
+		formats = append(formats, SurfaceFormat{
+			Format:     vkconv.FormatName(format.Format),
+			ColorSpace: vkconv.ColorSpaceName(format.ColorSpace),
+		})
+	}
+
+	modes := make([]string, 0, len(pd.SurfaceProps.presentModes))
+	for _, mode := range pd.SurfaceProps.presentModes {
+		modes = append(modes, vkconv.PresentModeName(mode))
+	}
+
+	capabilities := pd.SurfaceProps.capabilities
+
+	return Report{
+		Info:     pd.Info,
+		Suitable: len(problems) == 0,
+		Problems: problems,
+		Families: FamiliesReport{
+			Graphics:         pd.Families.supportGraphics,
+			Present:          pd.Families.supportPresent,
+			GraphicsFamilyID: pd.Families.GraphicsFamilyId,
+			PresentFamilyID:  pd.Families.PresentFamilyId,
+		},
+		Extensions:     extensions,
+		SurfaceFormats: formats,
+		PresentModes:   modes,
+		MinImageCount:  capabilities.MinImageCount,
+		MaxImageCount:  capabilities.MaxImageCount,
+	}
+}
@@ -1,46 +1,74 @@
 package physical
 
-import (
-	"log"
+import "log/slog"
 
-	"github.com/vulkan-go/vulkan"
+func (d *Device) score(pd *GPU) int {
+	problems := d.problems(pd)
 
-	"github.com/go-glx/vgl/internal/gpu/vlk/internal/vkconv"
-)
+	for _, reason := range problems {
+		d.logger.Debug("GPU not pass check",
+			slog.String("gpu", pd.Info.Name),
+			slog.String("reason", reason),
+		)
+	}
 
-func (d *Device) score(pd *GPU) int {
-	required := map[bool]string{
+	if len(problems) > 0 {
+		return -1
+	}
+
+	return scoreInfo(pd.Info)
+}
+
+// problems return list of reasons, why GPU cannot
+// be used for rendering (empty for suitable GPU)
+func (d *Device) problems(pd *GPU) []string {
+	checks := []struct {
+		failed bool
+		reason string
+	}{
 		// families
-		!pd.Families.supportGraphics: "graphics operations not supported",
-		!pd.Families.supportPresent:  "window present not supported",
+		{!pd.Families.supportGraphics, "graphics operations not supported"},
+		{!pd.Families.supportPresent, "window present not supported"},
 
 		// extensions
-		!pd.isSupportAllRequiredExtensions(): "not all required extensions supported",
+		{!pd.isSupportAllRequiredExtensions(d.logger), "not all required extensions supported"},
 
 		// swap chain
-		len(pd.SurfaceProps.formats) <= 0:             "not GPU",
-		len(pd.SurfaceProps.presentModes) <= 0:        "not GPU",
-		pd.SurfaceProps.RichColorSpaceFormat() == nil: "rich colorSpace not supported",
+		{len(pd.SurfaceProps.formats) <= 0, "no surface formats"},
+		{len(pd.SurfaceProps.presentModes) <= 0, "no present modes"},
+		{!pd.SurfaceProps.hasSurfaceFormat(), "no acceptable surface format"},
 	}
 
-	// filter
-	for failed, reason := range required {
-		if failed {
-			log.Printf(
-				"vk: GPU '%s' not pass check: %s\n",
-				vkconv.VarcharAsString(pd.Props.DeviceName),
-				reason,
-			)
-
-			return -1
+	problems := make([]string, 0)
+	for _, check := range checks {
+		if check.failed {
+			problems = append(problems, check.reason)
 		}
 	}
 
-	// score
+	return problems
+}
+
+// scoreInfo rate suitable GPU. Device type is most important,
+// VRAM and limits is used for choosing between GPU's of same type
+func scoreInfo(info Info) int {
 	score := 0
-	if pd.Props.DeviceType == vulkan.PhysicalDeviceTypeDiscreteGpu {
+
+	switch info.Type {
+	case TypeDiscrete:
+		score += 10000
+	case TypeIntegrated:
+		score += 5000
+	case TypeVirtual:
 		score += 1000
 	}
 
+	// +1 for every 256MB of VRAM (up to 64GB)
+	const mb256 = 256 * 1024 * 1024
+	score += int(min(info.DeviceLocalMemory()/mb256, 256))
+
+	// +1 for every 1024 pixels of max texture size
+	score += int(info.Limits.MaxImageDimension2D / 1024)
+
 	return score
 }
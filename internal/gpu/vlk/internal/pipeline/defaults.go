@@ -27,6 +27,11 @@ func (f *Factory) withDefaultMainRenderPass() Initializer {
 
 func (f *Factory) withDefaultLayout() Initializer {
 	return func(info *vulkan.GraphicsPipelineCreateInfo) {
+		if info.Layout != nil {
+			// already set with WithLayout
+			return
+		}
+
 		info.Layout = f.defaultPipelineLayout
 	}
 }
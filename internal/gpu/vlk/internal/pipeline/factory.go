@@ -1,8 +1,11 @@
 package pipeline
 
 import (
+	"fmt"
+
 	"github.com/vulkan-go/vulkan"
 
+	"github.com/go-glx/vgl/internal/gpu/vlk/internal/debugutils"
 	"github.com/go-glx/vgl/internal/gpu/vlk/internal/logical"
 	"github.com/go-glx/vgl/internal/gpu/vlk/internal/must"
 	"github.com/go-glx/vgl/internal/gpu/vlk/internal/renderpass"
@@ -10,19 +13,25 @@ import (
 )
 
 type Factory struct {
+	names          *debugutils.Names
 	ld             *logical.Device
 	swapChain      *swapchain.Chain
 	mainRenderPass *renderpass.Pass
 
 	defaultPipelineLayout vulkan.PipelineLayout
-	createdPipelines      []vulkan.Pipeline
+	createdLayouts        map[string]vulkan.PipelineLayout // by LayoutDesc key
+	createdPipelines      map[string]vulkan.Pipeline
 }
 
-func NewFactory(ld *logical.Device, swapChain *swapchain.Chain, mainRenderPass *renderpass.Pass) *Factory {
+func NewFactory(names *debugutils.Names, ld *logical.Device, swapChain *swapchain.Chain, mainRenderPass *renderpass.Pass) *Factory {
 	factory := &Factory{
+		names:          names,
 		ld:             ld,
 		swapChain:      swapChain,
 		mainRenderPass: mainRenderPass,
+
+		createdLayouts:   make(map[string]vulkan.PipelineLayout),
+		createdPipelines: make(map[string]vulkan.Pipeline),
 	}
 
 	factory.defaultPipelineLayout = factory.newDefaultPipelineLayout()
@@ -32,12 +41,61 @@ func NewFactory(ld *logical.Device, swapChain *swapchain.Chain, mainRenderPass *
 func (f *Factory) Free() {
 	vulkan.DestroyPipelineLayout(f.ld.Ref(), f.defaultPipelineLayout, nil)
 
+	for _, layout := range f.createdLayouts {
+		vulkan.DestroyPipelineLayout(f.ld.Ref(), layout, nil)
+	}
+
 	for _, pipeline := range f.createdPipelines {
 		vulkan.DestroyPipeline(f.ld.Ref(), pipeline, nil)
 	}
 }
 
-func (f *Factory) NewPipeline(opts ...Initializer) vulkan.Pipeline {
+// Pipeline return graphics pipeline by name, pipeline will be
+// created with opts on first call and cached until factory is
+// freed (swapchain rebuild). Name is also visible in GPU debuggers
+func (f *Factory) Pipeline(name string, opts ...Initializer) vulkan.Pipeline {
+	if pipeline, exist := f.createdPipelines[name]; exist {
+		return pipeline
+	}
+
+	pipeline := f.createPipeline(name, opts...)
+	f.createdPipelines[name] = pipeline
+	return pipeline
+}
+
+// Recreate replace already created pipeline with new one, created
+// from opts. Previous pipeline is destroyed only when new pipeline
+// created successfully, otherwise error is returned and previous
+// pipeline is still used. Not created pipelines is skipped, it will
+// be created on first use. GPU should not use previous pipeline
+func (f *Factory) Recreate(name string, opts ...Initializer) (err error) {
+	prev, exist := f.createdPipelines[name]
+	if !exist {
+		return nil
+	}
+
+	defer func() {
+		recovered := recover()
+		if recovered == nil {
+			return
+		}
+
+		recoveredErr, ok := recovered.(error)
+		if !ok {
+			panic(recovered)
+		}
+
+		err = fmt.Errorf("failed create pipeline '%s': %w", name, recoveredErr)
+	}()
+
+	pipeline := f.createPipeline(name, opts...)
+	vulkan.DestroyPipeline(f.ld.Ref(), prev, nil)
+
+	f.createdPipelines[name] = pipeline
+	return nil
+}
+
+func (f *Factory) createPipeline(name string, opts ...Initializer) vulkan.Pipeline {
 	info := vulkan.GraphicsPipelineCreateInfo{
 		SType: vulkan.StructureTypeGraphicsPipelineCreateInfo,
 	}
@@ -66,6 +124,7 @@ func (f *Factory) NewPipeline(opts ...Initializer) vulkan.Pipeline {
 	must.Work(result)
 
 	pipeline := pipelines[0]
-	f.createdPipelines = append(f.createdPipelines, pipeline)
+	f.names.Pipeline(pipeline, "pipeline."+name)
+
 	return pipeline
 }
@@ -0,0 +1,70 @@
+package pipeline
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/vulkan-go/vulkan"
+
+	"github.com/go-glx/vgl/internal/gpu/vlk/internal/must"
+)
+
+// LayoutDesc describe shader resources, visible in pipeline.
+// Every shader has own layout desc (from meta), but shaders
+// with equal desc share one layout
+type LayoutDesc struct {
+	// descriptor set layouts, where index is set number
+	SetLayouts    []vulkan.DescriptorSetLayout
+	PushConstants []vulkan.PushConstantRange
+}
+
+// Layout return pipeline layout for desc, layout is created
+// on first call and cached until factory is freed. Empty desc
+// is default layout without any resources
+func (f *Factory) Layout(desc LayoutDesc) vulkan.PipelineLayout {
+	if desc.empty() {
+		return f.defaultPipelineLayout
+	}
+
+	key := desc.key()
+	if layout, exist := f.createdLayouts[key]; exist {
+		return layout
+	}
+
+	layout := f.newLayout(desc)
+	f.createdLayouts[key] = layout
+	return layout
+}
+
+func (f *Factory) newLayout(desc LayoutDesc) vulkan.PipelineLayout {
+	info := &vulkan.PipelineLayoutCreateInfo{
+		SType:                  vulkan.StructureTypePipelineLayoutCreateInfo,
+		SetLayoutCount:         uint32(len(desc.SetLayouts)),
+		PSetLayouts:            desc.SetLayouts,
+		PushConstantRangeCount: uint32(len(desc.PushConstants)),
+		PPushConstantRanges:    desc.PushConstants,
+	}
+
+	var pipelineLayout vulkan.PipelineLayout
+	must.Work(vulkan.CreatePipelineLayout(f.ld.Ref(), info, nil, &pipelineLayout))
+
+	return pipelineLayout
+}
+
+func (d LayoutDesc) empty() bool {
+	return len(d.SetLayouts) == 0 && len(d.PushConstants) == 0
+}
+
+func (d LayoutDesc) key() string {
+	var key strings.Builder
+
+	for _, setLayout := range d.SetLayouts {
+		key.WriteString(fmt.Sprintf("set:%p;", setLayout))
+	}
+
+	for _, pushRange := range d.PushConstants {
+		key.WriteString(fmt.Sprintf("pc:%d:%d:%d;", pushRange.StageFlags, pushRange.Offset, pushRange.Size))
+	}
+
+	return key.String()
+}
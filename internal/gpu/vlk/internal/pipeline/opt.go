@@ -11,6 +11,14 @@ func WithStages(stages []vulkan.PipelineShaderStageCreateInfo) Initializer {
 	}
 }
 
+// WithLayout override default pipeline layout,
+// layout should be created with Factory.Layout
+func WithLayout(layout vulkan.PipelineLayout) Initializer {
+	return func(info *vulkan.GraphicsPipelineCreateInfo) {
+		info.Layout = layout
+	}
+}
+
 func WithTopology(topology vulkan.PrimitiveTopology) Initializer {
 	return func(info *vulkan.GraphicsPipelineCreateInfo) {
 		info.PInputAssemblyState = &vulkan.PipelineInputAssemblyStateCreateInfo{
@@ -0,0 +1,83 @@
+package pipeline
+
+import (
+	"sort"
+	"unsafe"
+
+	"github.com/vulkan-go/vulkan"
+)
+
+// pushSegment is continuous part of push constants block,
+// covered by same set of ranges (same stages)
+type pushSegment struct {
+	offset uint32
+	size   uint32
+	stages vulkan.ShaderStageFlags
+}
+
+// PushConstants record push of data at offset into command buffer.
+// Layout should be created from same ranges. Vulkan require, that
+// every pushed byte has flags of all stages, which range cover it,
+// so data is split by ranges boundaries and pushed in several commands.
+// Bytes outside of all ranges is not pushed
+func PushConstants(
+	cb vulkan.CommandBuffer,
+	layout vulkan.PipelineLayout,
+	ranges []vulkan.PushConstantRange,
+	offset uint32,
+	data []byte,
+) {
+	for _, segment := range pushSegments(ranges, offset, uint32(len(data))) {
+		vulkan.CmdPushConstants(cb, layout,
+			segment.stages,
+			segment.offset,
+			segment.size,
+			unsafe.Pointer(&data[segment.offset-offset]),
+		)
+	}
+}
+
+func pushSegments(ranges []vulkan.PushConstantRange, offset, size uint32) []pushSegment {
+	end := offset + size
+
+	// all ranges boundaries inside pushed data
+	bounds := []uint32{offset, end}
+	for _, pushRange := range ranges {
+		for _, bound := range []uint32{pushRange.Offset, pushRange.Offset + pushRange.Size} {
+			if bound > offset && bound < end {
+				bounds = append(bounds, bound)
+			}
+		}
+	}
+
+	sort.Slice(bounds, func(i, j int) bool { return bounds[i] < bounds[j] })
+
+	segments := make([]pushSegment, 0, len(bounds))
+	for ind := 0; ind < len(bounds)-1; ind++ {
+		from, to := bounds[ind], bounds[ind+1]
+		if from == to {
+			continue
+		}
+
+		stages := vulkan.ShaderStageFlags(0)
+		for _, pushRange := range ranges {
+			if pushRange.Offset <= from && to <= pushRange.Offset+pushRange.Size {
+				stages |= pushRange.StageFlags
+			}
+		}
+
+		if stages == 0 {
+			continue
+		}
+
+		// merge with previous segment of same stages
+		if last := len(segments) - 1; last >= 0 && segments[last].stages == stages && segments[last].offset+segments[last].size == from {
+			segments[last].size += to - from
+			continue
+		}
+
+		segments = append(segments, pushSegment{offset: from, size: to - from, stages: stages})
+	}
+
+	return segments
+}
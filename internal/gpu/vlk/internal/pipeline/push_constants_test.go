@@ -0,0 +1,73 @@
+package pipeline
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/vulkan-go/vulkan"
+)
+
+func TestPushSegments(t *testing.T) {
+	vert := vulkan.ShaderStageFlags(vulkan.ShaderStageVertexBit)
+	frag := vulkan.ShaderStageFlags(vulkan.ShaderStageFragmentBit)
+
+	// mat4 transform (vertex) + vec4 color (both stages) + float time (fragment)
+	ranges := []vulkan.PushConstantRange{
+		{StageFlags: vert, Offset: 0, Size: 80},
+		{StageFlags: frag, Offset: 64, Size: 20},
+	}
+
+	tests := []struct {
+		name   string
+		offset uint32
+		size   uint32
+		want   []pushSegment
+	}{
+		{
+			name:   "whole block",
+			offset: 0,
+			size:   84,
+			want: []pushSegment{
+				{offset: 0, size: 64, stages: vert},
+				{offset: 64, size: 16, stages: vert | frag},
+				{offset: 80, size: 4, stages: frag},
+			},
+		},
+		{
+			name:   "only transform",
+			offset: 0,
+			size:   64,
+			want:   []pushSegment{{offset: 0, size: 64, stages: vert}},
+		},
+		{
+			name:   "only time",
+			offset: 80,
+			size:   4,
+			want:   []pushSegment{{offset: 80, size: 4, stages: frag}},
+		},
+		{
+			name:   "outside of ranges",
+			offset: 84,
+			size:   4,
+			want:   []pushSegment{},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := pushSegments(ranges, tt.offset, tt.size)
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("segments %+v, want %+v", got, tt.want)
+			}
+		})
+	}
+
+	merged := pushSegments([]vulkan.PushConstantRange{
+		{StageFlags: vert | frag, Offset: 0, Size: 16},
+		{StageFlags: vert | frag, Offset: 16, Size: 16},
+	}, 0, 32)
+
+	if want := []pushSegment{{offset: 0, size: 32, stages: vert | frag}}; !reflect.DeepEqual(merged, want) {
+		t.Errorf("adjacent ranges of same stages should be merged, got %+v", merged)
+	}
+}
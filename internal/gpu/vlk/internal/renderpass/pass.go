@@ -1,10 +1,11 @@
 package renderpass
 
 import (
-	"log"
+	"log/slog"
 
 	"github.com/vulkan-go/vulkan"
 
+	"github.com/go-glx/vgl/internal/gpu/vlk/internal/debugutils"
 	"github.com/go-glx/vgl/internal/gpu/vlk/internal/logical"
 	"github.com/go-glx/vgl/internal/gpu/vlk/internal/must"
 )
@@ -32,6 +33,8 @@ func (p *Pass) Ref() vulkan.RenderPass {
 }
 
 func createPass(
+	logger *slog.Logger,
+	names *debugutils.Names,
 	name string,
 	ld *logical.Device,
 	attachments []vulkan.AttachmentDescription,
@@ -50,8 +53,9 @@ func createPass(
 
 	var renderPass vulkan.RenderPass
 	must.Work(vulkan.CreateRenderPass(ld.Ref(), info, nil, &renderPass))
+	names.RenderPass(renderPass, "renderpass."+name)
 
-	log.Printf("vk: render pass '%s' created\n", name)
+	logger.Debug("render pass created", slog.String("name", name))
 
 	return renderPass
 }
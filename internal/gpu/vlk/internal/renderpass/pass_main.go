@@ -1,18 +1,23 @@
 package renderpass
 
 import (
+	"log/slog"
+
 	"github.com/vulkan-go/vulkan"
 
+	"github.com/go-glx/vgl/internal/gpu/vlk/internal/debugutils"
 	"github.com/go-glx/vgl/internal/gpu/vlk/internal/logical"
 	"github.com/go-glx/vgl/internal/gpu/vlk/internal/physical"
 )
 
 // NewMain return main render pass that used for rendering
 // buffers to window screen surface
-func NewMain(pd *physical.Device, ld *logical.Device) *Pass {
+func NewMain(logger *slog.Logger, names *debugutils.Names, pd *physical.Device, ld *logical.Device) *Pass {
 	return newPass(
 		ld,
 		createPass(
+			logger,
+			names,
 			"main",
 			ld,
 			mainAttachments(pd),
@@ -23,9 +28,11 @@ func NewMain(pd *physical.Device, ld *logical.Device) *Pass {
 }
 
 func mainAttachments(pd *physical.Device) []vulkan.AttachmentDescription {
+	surfaceFormat, _ := pd.PrimaryGPU().SurfaceProps.SurfaceFormat()
+
 	return []vulkan.AttachmentDescription{
 		{
-			Format:         pd.PrimaryGPU().SurfaceProps.RichColorSpaceFormat().Format,
+			Format:         surfaceFormat.Format,
 			Samples:        vulkan.SampleCount1Bit,
 			LoadOp:         vulkan.AttachmentLoadOpClear,
 			StoreOp:        vulkan.AttachmentStoreOpStore,
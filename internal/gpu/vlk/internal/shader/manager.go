@@ -1,50 +1,157 @@
 package shader
 
 import (
+	"errors"
 	"fmt"
-	"log"
+	"log/slog"
+	"sort"
 
 	"github.com/vulkan-go/vulkan"
 
+	"github.com/go-glx/vgl/driver"
+	"github.com/go-glx/vgl/internal/gpu/vlk/internal/debugutils"
 	"github.com/go-glx/vgl/internal/gpu/vlk/internal/def"
 	"github.com/go-glx/vgl/internal/gpu/vlk/internal/logical"
 	"github.com/go-glx/vgl/internal/gpu/vlk/internal/must"
+	"github.com/go-glx/vgl/internal/gpu/vlk/internal/spirv"
 	"github.com/go-glx/vgl/internal/gpu/vlk/internal/vkconv"
 )
 
 type Manager struct {
 	shaders map[string]*Shader
 
-	ld *logical.Device
+	// latest SPIR-V version supported by device
+	spirvVersion spirv.Version
+
+	logger *slog.Logger
+	ld     *logical.Device
+	names  *debugutils.Names
 }
 
-func NewManager(ld *logical.Device) *Manager {
+func NewManager(logger *slog.Logger, ld *logical.Device, names *debugutils.Names, spirvVersion spirv.Version) *Manager {
 	return &Manager{
-		shaders: make(map[string]*Shader),
+		shaders:      make(map[string]*Shader),
+		spirvVersion: spirvVersion,
 
-		ld: ld,
+		logger: logger,
+		ld:     ld,
+		names:  names,
 	}
 }
 
 func (m *Manager) Free() {
 	for _, shader := range m.shaders {
-		vulkan.DestroyShaderModule(m.ld.Ref(), shader.moduleVert.module, nil)
-		vulkan.DestroyShaderModule(m.ld.Ref(), shader.moduleFrag.module, nil)
+		m.Destroy(shader)
 	}
 
-	log.Printf("vk: freed: shaders\n")
+	m.logger.Debug("freed: shaders")
 }
 
-func (m *Manager) ShaderByID(id string) *Shader {
+var (
+	ErrShaderNotFound          = errors.New("shader not registered in manager")
+	ErrShaderAlreadyRegistered = driver.ErrShaderAlreadyRegistered
+	ErrInvalidShader           = errors.New("invalid shader")
+)
+
+func (m *Manager) ShaderByID(id string) (*Shader, error) {
 	if shader, exist := m.shaders[id]; exist {
-		return shader
+		return shader, nil
+	}
+
+	return nil, fmt.Errorf("shader '%s' cannot be executed: %w", id, ErrShaderNotFound)
+}
+
+// IDs return sorted ids of all registered shaders
+func (m *Manager) IDs() []string {
+	ids := make([]string, 0, len(m.shaders))
+	for id := range m.shaders {
+		ids = append(ids, id)
 	}
 
-	panic(fmt.Errorf("shader '%s' not registered in manager and cannot be executed", id))
+	sort.Strings(ids)
+	return ids
 }
 
-func (m *Manager) RegisterShader(meta *Meta) {
+func (m *Manager) Has(id string) bool {
+	_, exist := m.shaders[id]
+	return exist
+}
+
+// RegisterShader validate shader bytecode and create shader modules.
+// Invalid bytecode is returned as error wrapping ErrInvalidShader,
+// all other vulkan errors will panic, same as other managers
+func (m *Manager) RegisterShader(meta *Meta) error {
+	if err := m.Validate(meta); err != nil {
+		return err
+	}
+
 	m.shaders[meta.id] = m.createCompiledShader(meta)
+	return nil
+}
+
+// Compile validate shader and create its modules, without
+// registering it in manager. Unlike RegisterShader, vulkan
+// errors is returned, so caller can keep previous shader version
+func (m *Manager) Compile(meta *Meta) (*Shader, error) {
+	if err := m.Validate(meta); err != nil {
+		return nil, err
+	}
+
+	vert, err := m.tryCreateModule(meta.id, meta.vert, TypeVertexBit)
+	if err != nil {
+		return nil, err
+	}
+
+	frag, err := m.tryCreateModule(meta.id, meta.frag, TypeFragmentBit)
+	if err != nil {
+		vulkan.DestroyShaderModule(m.ld.Ref(), vert.module, nil)
+		return nil, err
+	}
+
+	return &Shader{
+		meta:       meta,
+		moduleVert: vert,
+		moduleFrag: frag,
+	}, nil
+}
+
+// Replace register compiled shader instead of
+// previous version, previous modules is destroyed.
+// GPU should not use previous version (see VLK.maintenance)
+func (m *Manager) Replace(shader *Shader) {
+	if prev, exist := m.shaders[shader.meta.id]; exist {
+		m.Destroy(prev)
+	}
+
+	m.shaders[shader.meta.id] = shader
+}
+
+// Destroy free shader modules, shader should
+// not be used (or registered) after this
+func (m *Manager) Destroy(shader *Shader) {
+	vulkan.DestroyShaderModule(m.ld.Ref(), shader.moduleVert.module, nil)
+	vulkan.DestroyShaderModule(m.ld.Ref(), shader.moduleFrag.module, nil)
+}
+
+// Validate check that both shader stages can be loaded
+// by device (see spirv.Validate), without creating modules
+func (m *Manager) Validate(meta *Meta) error {
+	stages := []struct {
+		byteCode   []byte
+		shaderType Type
+	}{
+		{byteCode: meta.vert, shaderType: TypeVertexBit},
+		{byteCode: meta.frag, shaderType: TypeFragmentBit},
+	}
+
+	for _, stage := range stages {
+		_, err := spirv.Validate(stage.byteCode, def.ShaderEntryPoint, stage.shaderType.ExecutionModel(), m.spirvVersion)
+		if err != nil {
+			return fmt.Errorf("shader '%s' %s: %w: %w", meta.id, stage.shaderType, ErrInvalidShader, err)
+		}
+	}
+
+	return nil
 }
 
 func (m *Manager) createCompiledShader(meta *Meta) *Shader {
@@ -55,6 +162,24 @@ func (m *Manager) createCompiledShader(meta *Meta) *Shader {
 	}
 }
 
+func (m *Manager) tryCreateModule(id string, byteCode []byte, shaderType Type) (module *Module, err error) {
+	defer func() {
+		recovered := recover()
+		if recovered == nil {
+			return
+		}
+
+		recoveredErr, ok := recovered.(error)
+		if !ok {
+			panic(recovered)
+		}
+
+		err = fmt.Errorf("shader '%s' %s: %w", id, shaderType, recoveredErr)
+	}()
+
+	return m.createModule(id, byteCode, shaderType), nil
+}
+
 func (m *Manager) createModule(id string, byteCode []byte, shaderType Type) *Module {
 	info := &vulkan.ShaderModuleCreateInfo{
 		SType:    vulkan.StructureTypeShaderModuleCreateInfo,
@@ -64,8 +189,13 @@ func (m *Manager) createModule(id string, byteCode []byte, shaderType Type) *Mod
 
 	var shaderModule vulkan.ShaderModule
 	must.Work(vulkan.CreateShaderModule(m.ld.Ref(), info, nil, &shaderModule))
+	m.names.ShaderModule(shaderModule, fmt.Sprintf("shader.%s.%s", id, shaderType))
 
-	log.Printf("vk: created shader '%s' of type '%s', len=%d\n", id, shaderType, len(byteCode))
+	m.logger.Debug("shader created",
+		slog.String("id", id),
+		slog.String("type", string(shaderType)),
+		slog.Int("len", len(byteCode)),
+	)
 	return &Module{
 		module: shaderModule,
 		stageInfo: vulkan.PipelineShaderStageCreateInfo{
@@ -0,0 +1,36 @@
+package shader
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/vulkan-go/vulkan"
+
+	"github.com/go-glx/vgl/internal/gpu/vlk/internal/spirv"
+)
+
+func TestManager_Validate(t *testing.T) {
+	vert := readShader(t, "rect.vert.spv")
+	frag := readShader(t, "rect.frag.spv")
+
+	mng := &Manager{spirvVersion: spirv.Version{Major: 1, Minor: 0}}
+
+	if err := mng.Validate(NewMeta("rect", vert, frag, vulkan.PrimitiveTopologyTriangleList, nil, nil, nil)); err != nil {
+		t.Fatalf("valid shader: %v", err)
+	}
+
+	tests := map[string]*Meta{
+		"swapped stages": NewMeta("swapped", frag, vert, vulkan.PrimitiveTopologyTriangleList, nil, nil, nil),
+		"truncated":      NewMeta("truncated", vert[:len(vert)-3], frag, vulkan.PrimitiveTopologyTriangleList, nil, nil, nil),
+		"not spirv":      NewMeta("text", []byte("void main() {}\n"), frag, vulkan.PrimitiveTopologyTriangleList, nil, nil, nil),
+	}
+
+	for name, meta := range tests {
+		t.Run(name, func(t *testing.T) {
+			err := mng.Validate(meta)
+			if !errors.Is(err, ErrInvalidShader) || !errors.Is(err, spirv.ErrInvalidModule) {
+				t.Errorf("expected invalid shader error, got %v", err)
+			}
+		})
+	}
+}
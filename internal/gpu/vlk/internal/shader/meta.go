@@ -1,6 +1,10 @@
 package shader
 
-import "github.com/vulkan-go/vulkan"
+import (
+	"fmt"
+
+	"github.com/vulkan-go/vulkan"
+)
 
 type Meta struct {
 	id   string
@@ -10,6 +14,23 @@ type Meta struct {
 	topology   vulkan.PrimitiveTopology
 	bindings   []vulkan.VertexInputBindingDescription
 	attributes []vulkan.VertexInputAttributeDescription
+
+	// push constant ranges, declared by stages.
+	// Empty when shader not use push constants
+	pushConstants []vulkan.PushConstantRange
+
+	// vertices drawn without vertex buffer, for shaders
+	// without vertex input (zero for all other shaders)
+	vertexCount uint32
+
+	// interface derived from bytecode, nil
+	// for shaders created with hand-written meta
+	reflection *Reflection
+
+	// names of files, stages is loaded from (see Watcher),
+	// empty name is same as shader id
+	vertSource string
+	fragSource string
 }
 
 func NewMeta(
@@ -19,6 +40,7 @@ func NewMeta(
 	topology vulkan.PrimitiveTopology,
 	bindings []vulkan.VertexInputBindingDescription,
 	attributes []vulkan.VertexInputAttributeDescription,
+	pushConstants []vulkan.PushConstantRange,
 ) *Meta {
 	return &Meta{
 		id:         id,
@@ -27,13 +49,142 @@ func NewMeta(
 		topology:   topology,
 		bindings:   bindings,
 		attributes: attributes,
+
+		pushConstants: pushConstants,
+	}
+}
+
+// NewReflectedMeta create meta with vertex input and
+// push constants derived from shader bytecode
+func NewReflectedMeta(
+	id string,
+	vert []byte,
+	frag []byte,
+	topology vulkan.PrimitiveTopology,
+) (*Meta, error) {
+	reflection, err := Reflect(vert, frag)
+	if err != nil {
+		return nil, fmt.Errorf("shader '%s': %w: %w", id, ErrInvalidShader, err)
+	}
+
+	meta := NewMeta(
+		id,
+		vert,
+		frag,
+		topology,
+		reflection.Bindings,
+		reflection.Attributes,
+		reflection.PushConstants,
+	)
+
+	meta.reflection = reflection
+	return meta, nil
+}
+
+// WithVertexInput return copy of meta with
+// overridden (not reflected) vertex input
+func (s *Meta) WithVertexInput(
+	bindings []vulkan.VertexInputBindingDescription,
+	attributes []vulkan.VertexInputAttributeDescription,
+) *Meta {
+	meta := *s
+	meta.bindings = bindings
+	meta.attributes = attributes
+
+	return &meta
+}
+
+// WithPushConstantsSize return copy of meta with one
+// push constants range [0, size) visible in vertex and
+// fragment stages, instead of reflected ranges
+func (s *Meta) WithPushConstantsSize(size uint32) *Meta {
+	meta := *s
+	meta.pushConstants = []vulkan.PushConstantRange{
+		{
+			StageFlags: vulkan.ShaderStageFlags(vulkan.ShaderStageVertexBit | vulkan.ShaderStageFragmentBit),
+			Offset:     0,
+			Size:       size,
+		},
+	}
+
+	return &meta
+}
+
+// WithVertexCount return copy of meta with count of vertices,
+// drawn by shader without vertex input (from gl_VertexIndex)
+func (s *Meta) WithVertexCount(count uint32) *Meta {
+	meta := *s
+	meta.vertexCount = count
+
+	return &meta
+}
+
+// WithByteCode return copy of meta with new stages bytecode,
+// nil stage is not changed. Shader interface (vertex input,
+// push constants, etc..) is not reflected again (see Reload)
+func (s *Meta) WithByteCode(vert, frag []byte) *Meta {
+	meta := *s
+	if vert != nil {
+		meta.vert = vert
+	}
+
+	if frag != nil {
+		meta.frag = frag
 	}
+
+	return &meta
 }
 
+// WithSources return copy of meta with names of stage files,
+// for shaders that share stage with other shader
+func (s *Meta) WithSources(vert, frag string) *Meta {
+	meta := *s
+	meta.vertSource = vert
+	meta.fragSource = frag
+
+	return &meta
+}
+
+// Reload return copy of meta with new stages bytecode (nil stage
+// is not changed). Reflected meta is reflected again, and new
+// bytecode should have same interface, because pipeline layout
+// and draw data is based on it
+func (s *Meta) Reload(vert, frag []byte) (*Meta, error) {
+	meta := s.WithByteCode(vert, frag)
+	if s.reflection == nil {
+		return meta, nil
+	}
+
+	reflection, err := Reflect(meta.vert, meta.frag)
+	if err != nil {
+		return nil, fmt.Errorf("shader '%s': %w: %w", s.id, ErrInvalidShader, err)
+	}
+
+	if err = s.reflection.Compatible(reflection); err != nil {
+		return nil, fmt.Errorf("shader '%s': %w: interface is not compatible: %w", s.id, ErrInvalidShader, err)
+	}
+
+	meta.reflection = reflection
+	return meta, nil
+}
 func (s *Meta) ID() string {
 	return s.id
 }
 
+// Source return name of file, shader stage is loaded from
+func (s *Meta) Source(shaderType Type) string {
+	source := s.fragSource
+	if shaderType == TypeVertexBit {
+		source = s.vertSource
+	}
+
+	if source == "" {
+		return s.id
+	}
+
+	return source
+}
+
 func (s *Meta) Topology() vulkan.PrimitiveTopology {
 	return s.topology
 }
@@ -45,3 +196,28 @@ func (s *Meta) Bindings() []vulkan.VertexInputBindingDescription {
 func (s *Meta) Attributes() []vulkan.VertexInputAttributeDescription {
 	return s.attributes
 }
+
+func (s *Meta) VertexCount() uint32 {
+	return s.vertexCount
+}
+
+func (s *Meta) PushConstants() []vulkan.PushConstantRange {
+	return s.pushConstants
+}
+
+// PushConstantsSize is size of push constants block,
+// that cover all ranges (from zero offset)
+func (s *Meta) PushConstantsSize() uint32 {
+	size := uint32(0)
+	for _, pushRange := range s.pushConstants {
+		size = max(size, pushRange.Offset+pushRange.Size)
+	}
+
+	return size
+}
+
+// Reflection return shader interface derived from
+// bytecode, or nil when meta is hand-written
+func (s *Meta) Reflection() *Reflection {
+	return s.reflection
+}
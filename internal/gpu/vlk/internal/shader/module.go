@@ -1,6 +1,10 @@
 package shader
 
-import "github.com/vulkan-go/vulkan"
+import (
+	"unsafe"
+
+	"github.com/vulkan-go/vulkan"
+)
 
 type Module struct {
 	module    vulkan.ShaderModule
@@ -14,3 +18,31 @@ func (m *Module) Module() vulkan.ShaderModule {
 func (m *Module) Stage() *vulkan.PipelineShaderStageCreateInfo {
 	return &m.stageInfo
 }
+
+// SpecializedStage return copy of stage info with specialization
+// constants, where value of constant_id=N is constants[N].
+// All constants should be 32-bit (bool, int, uint, float)
+func (m *Module) SpecializedStage(constants []uint32) vulkan.PipelineShaderStageCreateInfo {
+	stage := m.stageInfo
+	if len(constants) == 0 {
+		return stage
+	}
+
+	entries := make([]vulkan.SpecializationMapEntry, 0, len(constants))
+	for id := range constants {
+		entries = append(entries, vulkan.SpecializationMapEntry{
+			ConstantID: uint32(id),
+			Offset:     uint32(id * 4),
+			Size:       4,
+		})
+	}
+
+	stage.PSpecializationInfo = []vulkan.SpecializationInfo{{
+		MapEntryCount: uint32(len(entries)),
+		PMapEntries:   entries,
+		DataSize:      uint(len(constants) * 4),
+		PData:         unsafe.Pointer(&constants[0]),
+	}}
+
+	return stage
+}
@@ -0,0 +1,294 @@
+package shader
+
+import (
+	"fmt"
+	"slices"
+	"sort"
+
+	"github.com/vulkan-go/vulkan"
+
+	"github.com/go-glx/vgl/internal/gpu/vlk/internal/spirv"
+)
+
+type (
+	// Reflection is shader interface, derived from SPIR-V bytecode
+	Reflection struct {
+		// vertex input, all attributes is tightly
+		// packed into one binding in location order
+		Bindings   []vulkan.VertexInputBindingDescription
+		Attributes []vulkan.VertexInputAttributeDescription
+
+		// descriptor set layouts, sorted by set
+		DescriptorSets []DescriptorSetLayout
+
+		// uniform buffers from descriptor sets,
+		// sorted by set and binding
+		UniformBlocks []UniformBlock
+
+		// push constant ranges, one for every stage that use it
+		PushConstants []vulkan.PushConstantRange
+	}
+
+	DescriptorSetLayout struct {
+		Set      uint32
+		Bindings []vulkan.DescriptorSetLayoutBinding
+	}
+
+	// UniformBlock is layout(set, binding) uniform block
+	UniformBlock struct {
+		Set     uint32
+		Binding uint32
+		Size    uint32 // biggest size of block in all stages
+	}
+
+	vertexFormat struct {
+		kind   spirv.Kind
+		vector uint32
+	}
+)
+
+var vertexFormats = map[vertexFormat]vulkan.Format{
+	{kind: spirv.KindFloat, vector: 1}: vulkan.FormatR32Sfloat,
+	{kind: spirv.KindFloat, vector: 2}: vulkan.FormatR32g32Sfloat,
+	{kind: spirv.KindFloat, vector: 3}: vulkan.FormatR32g32b32Sfloat,
+	{kind: spirv.KindFloat, vector: 4}: vulkan.FormatR32g32b32a32Sfloat,
+	{kind: spirv.KindInt, vector: 1}:   vulkan.FormatR32Sint,
+	{kind: spirv.KindInt, vector: 2}:   vulkan.FormatR32g32Sint,
+	{kind: spirv.KindInt, vector: 3}:   vulkan.FormatR32g32b32Sint,
+	{kind: spirv.KindInt, vector: 4}:   vulkan.FormatR32g32b32a32Sint,
+	{kind: spirv.KindUint, vector: 1}:  vulkan.FormatR32Uint,
+	{kind: spirv.KindUint, vector: 2}:  vulkan.FormatR32g32Uint,
+	{kind: spirv.KindUint, vector: 3}:  vulkan.FormatR32g32b32Uint,
+	{kind: spirv.KindUint, vector: 4}:  vulkan.FormatR32g32b32a32Uint,
+}
+
+var descriptorTypes = map[spirv.DescriptorType]vulkan.DescriptorType{
+	spirv.DescriptorUniformBuffer:        vulkan.DescriptorTypeUniformBuffer,
+	spirv.DescriptorStorageBuffer:        vulkan.DescriptorTypeStorageBuffer,
+	spirv.DescriptorCombinedImageSampler: vulkan.DescriptorTypeCombinedImageSampler,
+	spirv.DescriptorSampledImage:         vulkan.DescriptorTypeSampledImage,
+	spirv.DescriptorStorageImage:         vulkan.DescriptorTypeStorageImage,
+	spirv.DescriptorSampler:              vulkan.DescriptorTypeSampler,
+}
+
+// Reflect parse vertex and fragment bytecode
+// and build shader interface from it
+func Reflect(vert, frag []byte) (*Reflection, error) {
+	vertModule, err := spirv.Parse(vert)
+	if err != nil {
+		return nil, fmt.Errorf("vertex stage: %w", err)
+	}
+
+	fragModule, err := spirv.Parse(frag)
+	if err != nil {
+		return nil, fmt.Errorf("fragment stage: %w", err)
+	}
+
+	reflection := &Reflection{}
+
+	if err = reflection.addVertexInput(vertModule.Inputs); err != nil {
+		return nil, fmt.Errorf("vertex input: %w", err)
+	}
+
+	stages := []struct {
+		module *spirv.Module
+		stage  vulkan.ShaderStageFlagBits
+	}{
+		{module: vertModule, stage: vulkan.ShaderStageVertexBit},
+		{module: fragModule, stage: vulkan.ShaderStageFragmentBit},
+	}
+
+	for _, st := range stages {
+		if err = reflection.addDescriptors(st.module.Descriptors, st.stage); err != nil {
+			return nil, err
+		}
+
+		reflection.addPushConstants(st.module.PushConstants, st.stage)
+	}
+
+	sort.Slice(reflection.DescriptorSets, func(i, j int) bool {
+		return reflection.DescriptorSets[i].Set < reflection.DescriptorSets[j].Set
+	})
+
+	sort.Slice(reflection.UniformBlocks, func(i, j int) bool {
+		a, b := reflection.UniformBlocks[i], reflection.UniformBlocks[j]
+		if a.Set != b.Set {
+			return a.Set < b.Set
+		}
+
+		return a.Binding < b.Binding
+	})
+
+	return reflection, nil
+}
+
+func (r *Reflection) addVertexInput(inputs []spirv.Variable) error {
+	offset := uint32(0)
+
+	for _, input := range inputs {
+		if input.Type.Array > 0 || input.Type.Columns > 1 || input.Type.Width != 32 {
+			return fmt.Errorf("location %d ('%s'): only 32-bit scalars and vectors is supported", input.Location, input.Name)
+		}
+
+		format, ok := vertexFormats[vertexFormat{kind: input.Type.Kind, vector: input.Type.Vector}]
+		if !ok {
+			return fmt.Errorf("location %d ('%s'): unsupported type", input.Location, input.Name)
+		}
+
+		r.Attributes = append(r.Attributes, vulkan.VertexInputAttributeDescription{
+			Location: input.Location,
+			Binding:  0,
+			Format:   format,
+			Offset:   offset,
+		})
+
+		offset += input.Type.Size
+	}
+
+	if offset > 0 {
+		r.Bindings = append(r.Bindings, vulkan.VertexInputBindingDescription{
+			Binding:   0,
+			Stride:    offset,
+			InputRate: vulkan.VertexInputRateVertex,
+		})
+	}
+
+	return nil
+}
+
+func (r *Reflection) addDescriptors(descriptors []spirv.Descriptor, stage vulkan.ShaderStageFlagBits) error {
+	for _, descriptor := range descriptors {
+		descriptorType, ok := descriptorTypes[descriptor.Type]
+		if !ok {
+			return fmt.Errorf("descriptor '%s': unsupported type %d", descriptor.Name, descriptor.Type)
+		}
+
+		if descriptor.Type == spirv.DescriptorUniformBuffer && descriptor.Block != nil {
+			r.addUniformBlock(descriptor)
+		}
+
+		layout := r.descriptorSet(descriptor.Set)
+		binding := findBinding(layout, descriptor.Binding)
+
+		if binding == nil {
+			layout.Bindings = append(layout.Bindings, vulkan.DescriptorSetLayoutBinding{
+				Binding:         descriptor.Binding,
+				DescriptorType:  descriptorType,
+				DescriptorCount: descriptor.Count,
+				StageFlags:      vulkan.ShaderStageFlags(stage),
+			})
+
+			continue
+		}
+
+		// same resource used in several stages
+		if binding.DescriptorType != descriptorType || binding.DescriptorCount != descriptor.Count {
+			return fmt.Errorf("descriptor set=%d binding=%d: declared with different types in stages",
+				descriptor.Set, descriptor.Binding,
+			)
+		}
+
+		binding.StageFlags |= vulkan.ShaderStageFlags(stage)
+	}
+
+	return nil
+}
+
+func (r *Reflection) addPushConstants(block *spirv.Block, stage vulkan.ShaderStageFlagBits) {
+	if block == nil || len(block.Members) == 0 {
+		return
+	}
+
+	offset := block.Members[0].Offset
+	for _, member := range block.Members {
+		offset = min(offset, member.Offset)
+	}
+
+	r.PushConstants = append(r.PushConstants, vulkan.PushConstantRange{
+		StageFlags: vulkan.ShaderStageFlags(stage),
+		Offset:     offset,
+		Size:       block.Size - offset,
+	})
+}
+
+// addUniformBlock remember size of uniform block, stages can
+// declare only used part of block (up to last used member)
+func (r *Reflection) addUniformBlock(descriptor spirv.Descriptor) {
+	for ind := range r.UniformBlocks {
+		block := &r.UniformBlocks[ind]
+
+		if block.Set == descriptor.Set && block.Binding == descriptor.Binding {
+			block.Size = max(block.Size, descriptor.Block.Size)
+			return
+		}
+	}
+
+	r.UniformBlocks = append(r.UniformBlocks, UniformBlock{
+		Set:     descriptor.Set,
+		Binding: descriptor.Binding,
+		Size:    descriptor.Block.Size,
+	})
+}
+
+func (r *Reflection) descriptorSet(set uint32) *DescriptorSetLayout {
+	for ind := range r.DescriptorSets {
+		if r.DescriptorSets[ind].Set == set {
+			return &r.DescriptorSets[ind]
+		}
+	}
+
+	r.DescriptorSets = append(r.DescriptorSets, DescriptorSetLayout{Set: set})
+	return &r.DescriptorSets[len(r.DescriptorSets)-1]
+}
+
+func findBinding(layout *DescriptorSetLayout, binding uint32) *vulkan.DescriptorSetLayoutBinding {
+	for ind := range layout.Bindings {
+		if layout.Bindings[ind].Binding == binding {
+			return &layout.Bindings[ind]
+		}
+	}
+
+	return nil
+}
+
+// Compatible return error, when other shader interface differs from
+// this one: vertex input, descriptors, uniform blocks or push constants.
+// Shaders with same interface can be used with same pipeline layout
+// and draw data (for example, after hot reload)
+func (r *Reflection) Compatible(other *Reflection) error {
+	if !slices.Equal(r.Bindings, other.Bindings) || !slices.Equal(r.Attributes, other.Attributes) {
+		return fmt.Errorf("vertex input changed")
+	}
+
+	if !slices.Equal(r.PushConstants, other.PushConstants) {
+		return fmt.Errorf("push constants changed")
+	}
+
+	if !slices.Equal(r.UniformBlocks, other.UniformBlocks) {
+		return fmt.Errorf("uniform blocks changed")
+	}
+
+	if len(r.DescriptorSets) != len(other.DescriptorSets) {
+		return fmt.Errorf("descriptor sets changed")
+	}
+
+	for ind, set := range r.DescriptorSets {
+		otherSet := other.DescriptorSets[ind]
+		if set.Set != otherSet.Set || len(set.Bindings) != len(otherSet.Bindings) {
+			return fmt.Errorf("descriptor set=%d changed", set.Set)
+		}
+
+		// bindings is in order of declaration in stages
+		for _, binding := range set.Bindings {
+			otherBinding := findBinding(&otherSet, binding.Binding)
+			if otherBinding == nil ||
+				otherBinding.DescriptorType != binding.DescriptorType ||
+				otherBinding.DescriptorCount != binding.DescriptorCount ||
+				otherBinding.StageFlags != binding.StageFlags {
+				return fmt.Errorf("descriptor set=%d binding=%d changed", set.Set, binding.Binding)
+			}
+		}
+	}
+
+	return nil
+}
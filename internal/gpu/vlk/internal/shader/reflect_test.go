@@ -0,0 +1,159 @@
+package shader
+
+import (
+	"errors"
+	"os"
+	"reflect"
+	"testing"
+
+	"github.com/vulkan-go/vulkan"
+
+	"github.com/go-glx/vgl/internal/gpu/vlk/internal/spirv"
+)
+
+func readShader(t *testing.T, name string) []byte {
+	t.Helper()
+
+	byteCode, err := os.ReadFile("../../shaders/" + name)
+	if err != nil {
+		t.Fatalf("failed read shader: %v", err)
+	}
+
+	return byteCode
+}
+
+func TestReflect_Rect(t *testing.T) {
+	reflection, err := Reflect(readShader(t, "rect.vert.spv"), readShader(t, "rect.frag.spv"))
+	if err != nil {
+		t.Fatalf("failed reflect: %v", err)
+	}
+
+	wantAttributes := []vulkan.VertexInputAttributeDescription{
+		{Location: 0, Binding: 0, Format: vulkan.FormatR32g32Sfloat, Offset: 0},
+		{Location: 1, Binding: 0, Format: vulkan.FormatR32g32b32Sfloat, Offset: 8},
+	}
+
+	if len(reflection.Attributes) != len(wantAttributes) {
+		t.Fatalf("got %d attributes, want %d", len(reflection.Attributes), len(wantAttributes))
+	}
+
+	for ind, attr := range reflection.Attributes {
+		if attr != wantAttributes[ind] {
+			t.Errorf("attribute %d: %+v, want %+v", ind, attr, wantAttributes[ind])
+		}
+	}
+
+	if len(reflection.Bindings) != 1 || reflection.Bindings[0].Stride != 20 {
+		t.Errorf("bindings: %+v, want one binding with stride 20", reflection.Bindings)
+	}
+
+	if len(reflection.DescriptorSets) != 0 || len(reflection.PushConstants) != 0 {
+		t.Errorf("unexpected resources: %+v, %+v", reflection.DescriptorSets, reflection.PushConstants)
+	}
+}
+
+func TestReflect_Triangle(t *testing.T) {
+	reflection, err := Reflect(readShader(t, "triangle.vert.spv"), readShader(t, "triangle.frag.spv"))
+	if err != nil {
+		t.Fatalf("failed reflect: %v", err)
+	}
+
+	// vertexes is hardcoded in shader
+	if len(reflection.Attributes) != 0 || len(reflection.Bindings) != 0 {
+		t.Errorf("unexpected vertex input: %+v, %+v", reflection.Bindings, reflection.Attributes)
+	}
+}
+
+func TestReflect_Invalid(t *testing.T) {
+	if _, err := Reflect(readShader(t, "rect.vert.spv"), []byte{1, 2, 3}); err == nil {
+		t.Errorf("expected error for broken fragment bytecode")
+	}
+}
+
+func TestReflection_PushConstants(t *testing.T) {
+	reflection := &Reflection{}
+
+	// layout(push_constant) uniform PC { mat4 transform; vec4 color; }
+	reflection.addPushConstants(&spirv.Block{
+		Size: 80,
+		Members: []spirv.Member{
+			{Name: "transform", Offset: 0},
+			{Name: "color", Offset: 64},
+		},
+	}, vulkan.ShaderStageVertexBit)
+
+	// fragment stage use only color member
+	reflection.addPushConstants(&spirv.Block{
+		Size: 80,
+		Members: []spirv.Member{
+			{Name: "color", Offset: 64},
+		},
+	}, vulkan.ShaderStageFragmentBit)
+
+	want := []vulkan.PushConstantRange{
+		{StageFlags: vulkan.ShaderStageFlags(vulkan.ShaderStageVertexBit), Offset: 0, Size: 80},
+		{StageFlags: vulkan.ShaderStageFlags(vulkan.ShaderStageFragmentBit), Offset: 64, Size: 16},
+	}
+
+	if !reflect.DeepEqual(reflection.PushConstants, want) {
+		t.Errorf("push constants %+v, want %+v", reflection.PushConstants, want)
+	}
+
+	meta := NewMeta("pc", nil, nil, vulkan.PrimitiveTopologyTriangleList, nil, nil, reflection.PushConstants)
+	if meta.PushConstantsSize() != 80 {
+		t.Errorf("push constants size %d, want 80", meta.PushConstantsSize())
+	}
+}
+
+func TestReflection_UniformBlocks(t *testing.T) {
+	reflection := &Reflection{}
+
+	// layout(set = 1, binding = 0) uniform Light { vec4 color; vec4 dir; }
+	// layout(set = 0, binding = 2) uniform Camera { mat4 proj; }
+	err := reflection.addDescriptors([]spirv.Descriptor{
+		{Name: "light", Set: 1, Binding: 0, Type: spirv.DescriptorUniformBuffer, Count: 1, Block: &spirv.Block{Size: 16}},
+		{Name: "camera", Set: 0, Binding: 2, Type: spirv.DescriptorUniformBuffer, Count: 1, Block: &spirv.Block{Size: 64}},
+	}, vulkan.ShaderStageVertexBit)
+	if err != nil {
+		t.Fatalf("failed add vertex descriptors: %v", err)
+	}
+
+	// fragment stage use whole light block, and texture
+	err = reflection.addDescriptors([]spirv.Descriptor{
+		{Name: "light", Set: 1, Binding: 0, Type: spirv.DescriptorUniformBuffer, Count: 1, Block: &spirv.Block{Size: 32}},
+		{Name: "tex", Set: 1, Binding: 1, Type: spirv.DescriptorCombinedImageSampler, Count: 1},
+	}, vulkan.ShaderStageFragmentBit)
+	if err != nil {
+		t.Fatalf("failed add fragment descriptors: %v", err)
+	}
+
+	want := []UniformBlock{
+		{Set: 1, Binding: 0, Size: 32},
+		{Set: 0, Binding: 2, Size: 64},
+	}
+
+	if !reflect.DeepEqual(reflection.UniformBlocks, want) {
+		t.Errorf("uniform blocks %+v, want %+v", reflection.UniformBlocks, want)
+	}
+}
+
+func TestMeta_Reload(t *testing.T) {
+	meta, err := NewReflectedMeta("rect", readShader(t, "rect.vert.spv"), readShader(t, "rect.frag.spv"), vulkan.PrimitiveTopologyTriangleList)
+	if err != nil {
+		t.Fatalf("failed reflect: %v", err)
+	}
+
+	// same interface
+	if _, err = meta.Reload(nil, readShader(t, "rect.frag.spv")); err != nil {
+		t.Errorf("reload with same interface: %v", err)
+	}
+
+	// triangle has no vertex input
+	if _, err = meta.Reload(readShader(t, "triangle.vert.spv"), nil); !errors.Is(err, ErrInvalidShader) {
+		t.Errorf("reload with changed vertex input: error %v, want %v", err, ErrInvalidShader)
+	}
+
+	if _, err = meta.Reload([]byte{1, 2, 3}, nil); !errors.Is(err, ErrInvalidShader) {
+		t.Errorf("reload with broken bytecode: error %v, want %v", err, ErrInvalidShader)
+	}
+}
@@ -4,6 +4,8 @@ import (
 	"fmt"
 
 	"github.com/vulkan-go/vulkan"
+
+	"github.com/go-glx/vgl/internal/gpu/vlk/internal/spirv"
 )
 
 type Type string
@@ -46,6 +48,24 @@ var typesMap = map[Type]vulkan.ShaderStageFlagBits{
 	TypeMeshBitNv:                 vulkan.ShaderStageMeshBitNv,
 }
 
+var executionModels = map[Type]spirv.ExecutionModel{
+	TypeVertexBit:                 spirv.ExecutionModelVertex,
+	TypeTessellationControlBit:    spirv.ExecutionModelTessellationControl,
+	TypeTessellationEvaluationBit: spirv.ExecutionModelTessellationEvaluation,
+	TypeGeometryBit:               spirv.ExecutionModelGeometry,
+	TypeFragmentBit:               spirv.ExecutionModelFragment,
+	TypeComputeBit:                spirv.ExecutionModelGLCompute,
+}
+
+// ExecutionModel is SPIR-V entry point model of shader stage
+func (t Type) ExecutionModel() spirv.ExecutionModel {
+	if model, exist := executionModels[t]; exist {
+		return model
+	}
+
+	panic(fmt.Errorf("shader type %s has no SPIR-V execution model", t))
+}
+
 func (t Type) VulkanShaderStage() vulkan.ShaderStageFlagBits {
 	if stage, exist := typesMap[t]; exist {
 		return stage
@@ -0,0 +1,111 @@
+package shader
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"time"
+)
+
+// how often watched files is checked for changes
+const watchInterval = 500 * time.Millisecond
+
+type (
+	// Watcher poll compiled shader files in dir, and report
+	// shaders changed since previous poll. Files is matched
+	// by stage source (see Meta.Source): "<source>.vert.spv" and
+	// "<source>.frag.spv", one file can be used by many shaders
+	Watcher struct {
+		dir      string
+		lastPoll time.Time
+		files    map[string]fileStamp
+	}
+
+	fileStamp struct {
+		modified time.Time
+		size     int64
+	}
+)
+
+func NewWatcher(dir string) *Watcher {
+	return &Watcher{
+		dir:   dir,
+		files: make(map[string]fileStamp),
+	}
+}
+
+// Changed return sorted ids of shaders with changed files.
+// Files seen first time is not changed, so shaders is not
+// reloaded on start. Files is checked not often than watchInterval
+func (w *Watcher) Changed(shaders []*Meta, now time.Time) []string {
+	if now.Sub(w.lastPoll) < watchInterval {
+		return nil
+	}
+
+	w.lastPoll = now
+	changed := make([]string, 0)
+
+	// every file is checked once per poll
+	checked := make(map[string]bool)
+	check := func(path string) bool {
+		if _, ok := checked[path]; !ok {
+			checked[path] = w.check(path)
+		}
+
+		return checked[path]
+	}
+
+	for _, meta := range shaders {
+		vert := check(w.path(meta.Source(TypeVertexBit), TypeVertexBit))
+		frag := check(w.path(meta.Source(TypeFragmentBit), TypeFragmentBit))
+
+		if vert || frag {
+			changed = append(changed, meta.ID())
+		}
+	}
+
+	sort.Strings(changed)
+	return changed
+}
+
+// Read return current bytecode of shader stage, or
+// nil when file not exist in watched dir
+func (w *Watcher) Read(meta *Meta, shaderType Type) ([]byte, error) {
+	path := w.path(meta.Source(shaderType), shaderType)
+
+	byteCode, err := os.ReadFile(path)
+	if os.IsNotExist(err) {
+		return nil, nil
+	}
+
+	if err != nil {
+		return nil, fmt.Errorf("failed read shader '%s' %s: %w", meta.ID(), shaderType, err)
+	}
+
+	return byteCode, nil
+}
+
+func (w *Watcher) path(source string, shaderType Type) string {
+	ext := "frag"
+	if shaderType == TypeVertexBit {
+		ext = "vert"
+	}
+
+	return filepath.Join(w.dir, fmt.Sprintf("%s.%s.spv", source, ext))
+}
+
+// check update file stamp and report that file is changed.
+// Not existing file has zero stamp, so created files is
+// changed, but removed is not (previous bytecode is kept)
+func (w *Watcher) check(path string) bool {
+	stamp := fileStamp{}
+	if info, err := os.Stat(path); err == nil {
+		stamp = fileStamp{modified: info.ModTime(), size: info.Size()}
+	}
+
+	prev, seen := w.files[path]
+	w.files[path] = stamp
+
+	return seen && prev != stamp && stamp != fileStamp{}
+}
@@ -0,0 +1,79 @@
+package shader
+
+import (
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+	"time"
+)
+
+func TestWatcher_Changed(t *testing.T) {
+	dir := t.TempDir()
+	write := func(name string, data string, modified time.Time) {
+		t.Helper()
+
+		path := filepath.Join(dir, name)
+		if err := os.WriteFile(path, []byte(data), 0o600); err != nil {
+			t.Fatalf("failed write: %v", err)
+		}
+
+		if err := os.Chtimes(path, modified, modified); err != nil {
+			t.Fatalf("failed touch: %v", err)
+		}
+	}
+
+	start := time.Now()
+	write("rect.vert.spv", "v1", start)
+	write("rect.frag.spv", "f1", start)
+
+	rect := NewMeta("rect", nil, nil, 0, nil, nil, nil)
+	sprite := NewMeta("sprite", nil, nil, 0, nil, nil, nil)
+	mesh := NewMeta("mesh", nil, nil, 0, nil, nil, nil).WithSources("mesh", "rect")
+
+	shaders := []*Meta{rect, sprite, mesh}
+	w := NewWatcher(dir)
+	now := start
+
+	poll := func() []string {
+		now = now.Add(watchInterval)
+		return w.Changed(shaders, now)
+	}
+
+	if changed := poll(); len(changed) != 0 {
+		t.Fatalf("files seen first time should not be changed, got %v", changed)
+	}
+
+	write("rect.frag.spv", "f2", start.Add(time.Second))
+	if changed := w.Changed(shaders, now.Add(watchInterval/2)); len(changed) != 0 {
+		t.Fatalf("poll before interval should be skipped, got %v", changed)
+	}
+
+	// mesh use rect fragment stage
+	if changed := poll(); !reflect.DeepEqual(changed, []string{"mesh", "rect"}) {
+		t.Fatalf("changed %v, want [mesh rect]", changed)
+	}
+
+	if changed := poll(); len(changed) != 0 {
+		t.Fatalf("not modified files should not be changed, got %v", changed)
+	}
+
+	write("sprite.vert.spv", "v1", start)
+	if changed := poll(); !reflect.DeepEqual(changed, []string{"sprite"}) {
+		t.Fatalf("created files should be changed, got %v", changed)
+	}
+
+	write("mesh.vert.spv", "v1", start)
+	if changed := poll(); !reflect.DeepEqual(changed, []string{"mesh"}) {
+		t.Fatalf("own stage of shader should change only it, got %v", changed)
+	}
+
+	byteCode, err := w.Read(mesh, TypeFragmentBit)
+	if err != nil || string(byteCode) != "f2" {
+		t.Fatalf("read %q (%v), want f2 (shared rect stage)", byteCode, err)
+	}
+
+	if byteCode, err = w.Read(sprite, TypeFragmentBit); err != nil || byteCode != nil {
+		t.Fatalf("not existing stage should be nil, got %q (%v)", byteCode, err)
+	}
+}
@@ -0,0 +1,517 @@
+package spirv
+
+import (
+	"fmt"
+	"math/bits"
+	"sort"
+)
+
+// opcodes used in reflection
+const (
+	opName             = 5
+	opMemberName       = 6
+	opEntryPoint       = 15
+	opTypeBool         = 20
+	opTypeInt          = 21
+	opTypeFloat        = 22
+	opTypeVector       = 23
+	opTypeMatrix       = 24
+	opTypeImage        = 25
+	opTypeSampler      = 26
+	opTypeSampledImage = 27
+	opTypeArray        = 28
+	opTypeRuntimeArray = 29
+	opTypeStruct       = 30
+	opTypePointer      = 32
+	opConstant         = 43
+	opVariable         = 59
+	opDecorate         = 71
+	opMemberDecorate   = 72
+)
+
+// decorations
+const (
+	decorationBlock         = 2
+	decorationBufferBlock   = 3
+	decorationArrayStride   = 6
+	decorationMatrixStride  = 7
+	decorationBuiltIn       = 11
+	decorationLocation      = 30
+	decorationBinding       = 33
+	decorationDescriptorSet = 34
+	decorationOffset        = 35
+)
+
+// storage classes
+const (
+	storageUniformConstant = 0
+	storageInput           = 1
+	storageUniform         = 2
+	storageOutput          = 3
+	storagePushConstant    = 9
+	storageStorageBuffer   = 12
+)
+
+// image dimensions
+const imageDimBuffer = 5
+
+// minimal operands count of parsed instructions
+var minOperands = map[uint32]int{
+	opName:           1,
+	opMemberName:     2,
+	opEntryPoint:     2,
+	opConstant:       3,
+	opVariable:       3,
+	opDecorate:       2,
+	opMemberDecorate: 3,
+}
+
+type (
+	// parser collect all ids info in one pass, module
+	// is built from it after all instructions is read
+	parser struct {
+		names       map[uint32]string
+		memberNames map[uint32]map[uint32]string
+		decorations map[uint32]map[uint32]uint32
+		members     map[uint32]map[uint32]map[uint32]uint32 // struct -> member -> decoration -> value
+		types       map[uint32]rawType
+		constants   map[uint32]uint32
+		variables   []rawVariable
+
+		module *Module
+	}
+
+	rawType struct {
+		op       uint32
+		operands []uint32
+	}
+
+	rawVariable struct {
+		id      uint32
+		typeID  uint32 // pointer type
+		storage uint32
+	}
+)
+
+// Parse read shader interface from SPIR-V bytecode
+func Parse(byteCode []byte) (*Module, error) {
+	words, err := Words(byteCode)
+	if err != nil {
+		return nil, err
+	}
+
+	return ParseWords(words)
+}
+
+// ParseWords read shader interface from SPIR-V words
+func ParseWords(words []uint32) (*Module, error) {
+	if len(words) < headerWords {
+		return nil, fmt.Errorf("%w: module is smaller than header (%d words)", ErrInvalidModule, len(words))
+	}
+
+	if words[0] != Magic {
+		if bits.ReverseBytes32(words[0]) != Magic {
+			return nil, fmt.Errorf("%w: bad magic number 0x%08x", ErrInvalidModule, words[0])
+		}
+
+		// big-endian module
+		swapped := make([]uint32, len(words))
+		for i, word := range words {
+			swapped[i] = bits.ReverseBytes32(word)
+		}
+
+		words = swapped
+	}
+
+	p := &parser{
+		names:       make(map[uint32]string),
+		memberNames: make(map[uint32]map[uint32]string),
+		decorations: make(map[uint32]map[uint32]uint32),
+		members:     make(map[uint32]map[uint32]map[uint32]uint32),
+		types:       make(map[uint32]rawType),
+		constants:   make(map[uint32]uint32),
+
+		module: &Module{
+			Version: Version{
+				Major: uint8(words[1] >> 16),
+				Minor: uint8(words[1] >> 8),
+			},
+		},
+	}
+
+	for offset := headerWords; offset < len(words); {
+		count := int(words[offset] >> 16)
+		opcode := words[offset] & 0xffff
+
+		if count == 0 || offset+count > len(words) {
+			return nil, fmt.Errorf("%w: broken instruction %d at word %d", ErrInvalidModule, opcode, offset)
+		}
+
+		if err := p.instruction(opcode, words[offset+1:offset+count]); err != nil {
+			return nil, fmt.Errorf("%w: instruction %d at word %d: %w", ErrInvalidModule, opcode, offset, err)
+		}
+
+		offset += count
+	}
+
+	if err := p.build(); err != nil {
+		return nil, fmt.Errorf("%w: %w", ErrInvalidModule, err)
+	}
+
+	return p.module, nil
+}
+
+func (p *parser) instruction(opcode uint32, operands []uint32) error {
+	if n, ok := minOperands[opcode]; ok && len(operands) < n {
+		return fmt.Errorf("expected at least %d operands, got %d", n, len(operands))
+	}
+
+	switch opcode {
+	case opName:
+		p.names[operands[0]], _ = literalString(operands[1:])
+	case opMemberName:
+		if p.memberNames[operands[0]] == nil {
+			p.memberNames[operands[0]] = make(map[uint32]string)
+		}
+
+		p.memberNames[operands[0]][operands[1]], _ = literalString(operands[2:])
+	case opEntryPoint:
+		name, _ := literalString(operands[2:])
+		p.module.EntryPoints = append(p.module.EntryPoints, EntryPoint{
+			Name:  name,
+			Model: ExecutionModel(operands[0]),
+		})
+	case opTypeBool, opTypeInt, opTypeFloat, opTypeVector, opTypeMatrix,
+		opTypeImage, opTypeSampler, opTypeSampledImage, opTypeArray,
+		opTypeRuntimeArray, opTypeStruct, opTypePointer:
+		if len(operands) < 1 {
+			return fmt.Errorf("type without result id")
+		}
+
+		p.types[operands[0]] = rawType{op: opcode, operands: operands[1:]}
+	case opConstant:
+		// only 32-bit constants is needed (array lengths)
+		p.constants[operands[1]] = operands[2]
+	case opVariable:
+		p.variables = append(p.variables, rawVariable{
+			id:      operands[1],
+			typeID:  operands[0],
+			storage: operands[2],
+		})
+	case opDecorate:
+		if p.decorations[operands[0]] == nil {
+			p.decorations[operands[0]] = make(map[uint32]uint32)
+		}
+
+		p.decorations[operands[0]][operands[1]] = optional(operands, 2)
+	case opMemberDecorate:
+		if p.members[operands[0]] == nil {
+			p.members[operands[0]] = make(map[uint32]map[uint32]uint32)
+		}
+
+		if p.members[operands[0]][operands[1]] == nil {
+			p.members[operands[0]][operands[1]] = make(map[uint32]uint32)
+		}
+
+		p.members[operands[0]][operands[1]][operands[2]] = optional(operands, 3)
+	}
+
+	return nil
+}
+
+func (p *parser) build() error {
+	for _, variable := range p.variables {
+		pointer, ok := p.types[variable.typeID]
+		if !ok || pointer.op != opTypePointer || len(pointer.operands) < 2 {
+			return fmt.Errorf("variable %d: type %d is not pointer", variable.id, variable.typeID)
+		}
+
+		typeID := pointer.operands[1]
+		decorations := p.decorations[variable.id]
+
+		switch variable.storage {
+		case storageInput, storageOutput:
+			location, hasLocation := decorations[decorationLocation]
+			if !hasLocation {
+				// built-ins (gl_Position, gl_VertexIndex, etc..)
+				continue
+			}
+
+			typ, err := p.typeOf(typeID)
+			if err != nil {
+				return fmt.Errorf("variable %d: %w", variable.id, err)
+			}
+
+			v := Variable{
+				Name:     p.names[variable.id],
+				Location: location,
+				Type:     typ,
+			}
+
+			if variable.storage == storageInput {
+				p.module.Inputs = append(p.module.Inputs, v)
+			} else {
+				p.module.Outputs = append(p.module.Outputs, v)
+			}
+		case storagePushConstant:
+			block, err := p.block(typeID)
+			if err != nil {
+				return fmt.Errorf("push constants %d: %w", variable.id, err)
+			}
+
+			p.module.PushConstants = block
+		case storageUniform, storageUniformConstant, storageStorageBuffer:
+			descriptor, err := p.descriptor(variable, typeID)
+			if err != nil {
+				return fmt.Errorf("descriptor %d: %w", variable.id, err)
+			}
+
+			p.module.Descriptors = append(p.module.Descriptors, descriptor)
+		}
+	}
+
+	sortVariables(p.module.Inputs)
+	sortVariables(p.module.Outputs)
+	sort.Slice(p.module.Descriptors, func(i, j int) bool {
+		a, b := p.module.Descriptors[i], p.module.Descriptors[j]
+		if a.Set != b.Set {
+			return a.Set < b.Set
+		}
+
+		return a.Binding < b.Binding
+	})
+
+	return nil
+}
+
+func (p *parser) descriptor(variable rawVariable, typeID uint32) (Descriptor, error) {
+	decorations := p.decorations[variable.id]
+	descriptor := Descriptor{
+		Name:    p.names[variable.id],
+		Set:     decorations[decorationDescriptorSet],
+		Binding: decorations[decorationBinding],
+		Count:   1,
+	}
+
+	// arrays of resources
+	if raw := p.types[typeID]; raw.op == opTypeArray && len(raw.operands) >= 2 {
+		descriptor.Count = p.constants[raw.operands[1]]
+		typeID = raw.operands[0]
+	}
+
+	raw, ok := p.types[typeID]
+	if !ok {
+		return descriptor, fmt.Errorf("unknown type %d", typeID)
+	}
+
+	switch raw.op {
+	case opTypeStruct:
+		block, err := p.block(typeID)
+		if err != nil {
+			return descriptor, err
+		}
+
+		descriptor.Block = block
+		descriptor.Type = DescriptorUniformBuffer
+
+		_, isBufferBlock := p.decorations[typeID][decorationBufferBlock]
+		if variable.storage == storageStorageBuffer || isBufferBlock {
+			descriptor.Type = DescriptorStorageBuffer
+		}
+	case opTypeSampledImage:
+		descriptor.Type = DescriptorCombinedImageSampler
+	case opTypeSampler:
+		descriptor.Type = DescriptorSampler
+	case opTypeImage:
+		// operands: sampled type, dim, depth, arrayed, ms, sampled
+		if len(raw.operands) < 6 {
+			return descriptor, fmt.Errorf("broken image type %d", typeID)
+		}
+
+		if raw.operands[1] == imageDimBuffer {
+			return descriptor, fmt.Errorf("texel buffers is not supported")
+		}
+
+		descriptor.Type = DescriptorSampledImage
+		if raw.operands[5] == 2 {
+			descriptor.Type = DescriptorStorageImage
+		}
+	default:
+		return descriptor, fmt.Errorf("unsupported resource type (op %d)", raw.op)
+	}
+
+	return descriptor, nil
+}
+
+func (p *parser) block(structID uint32) (*Block, error) {
+	raw, ok := p.types[structID]
+	if !ok || raw.op != opTypeStruct {
+		return nil, fmt.Errorf("type %d is not struct", structID)
+	}
+
+	typ, err := p.typeOf(structID)
+	if err != nil {
+		return nil, err
+	}
+
+	block := &Block{
+		Name:    p.names[structID],
+		Size:    typ.Size,
+		Members: make([]Member, 0, len(raw.operands)),
+	}
+
+	for ind, memberTypeID := range raw.operands {
+		memberType, err := p.memberType(structID, uint32(ind), memberTypeID)
+		if err != nil {
+			return nil, fmt.Errorf("member %d: %w", ind, err)
+		}
+
+		block.Members = append(block.Members, Member{
+			Name:   p.memberNames[structID][uint32(ind)],
+			Offset: p.members[structID][uint32(ind)][decorationOffset],
+			Type:   memberType,
+		})
+	}
+
+	return block, nil
+}
+
+func (p *parser) typeOf(typeID uint32) (Type, error) {
+	return p.typeWithStride(typeID, 0)
+}
+
+// memberType is type of struct member, with member
+// matrix stride decoration applied
+func (p *parser) memberType(structID, member, typeID uint32) (Type, error) {
+	return p.typeWithStride(typeID, p.members[structID][member][decorationMatrixStride])
+}
+
+func (p *parser) typeWithStride(typeID uint32, matrixStride uint32) (Type, error) {
+	raw, ok := p.types[typeID]
+	if !ok {
+		return Type{}, fmt.Errorf("unknown type %d", typeID)
+	}
+
+	switch raw.op {
+	case opTypeBool:
+		return Type{Kind: KindBool, Width: 32, Vector: 1, Columns: 1, Size: 4}, nil
+	case opTypeInt, opTypeFloat:
+		if len(raw.operands) < 1 {
+			return Type{}, fmt.Errorf("broken numeric type %d", typeID)
+		}
+
+		kind := KindFloat
+		if raw.op == opTypeInt {
+			kind = KindUint
+			if len(raw.operands) >= 2 && raw.operands[1] == 1 {
+				kind = KindInt
+			}
+		}
+
+		width := raw.operands[0]
+		return Type{Kind: kind, Width: width, Vector: 1, Columns: 1, Size: width / 8}, nil
+	case opTypeVector:
+		if len(raw.operands) < 2 {
+			return Type{}, fmt.Errorf("broken vector type %d", typeID)
+		}
+
+		component, err := p.typeOf(raw.operands[0])
+		if err != nil {
+			return Type{}, err
+		}
+
+		component.Vector = raw.operands[1]
+		component.Size *= component.Vector
+		return component, nil
+	case opTypeMatrix:
+		if len(raw.operands) < 2 {
+			return Type{}, fmt.Errorf("broken matrix type %d", typeID)
+		}
+
+		column, err := p.typeOf(raw.operands[0])
+		if err != nil {
+			return Type{}, err
+		}
+
+		stride := column.Size
+		if matrixStride > 0 {
+			stride = matrixStride
+		}
+
+		column.Columns = raw.operands[1]
+		column.Size = stride * column.Columns
+		return column, nil
+	case opTypeArray, opTypeRuntimeArray:
+		if len(raw.operands) < 1 {
+			return Type{}, fmt.Errorf("broken array type %d", typeID)
+		}
+
+		element, err := p.typeWithStride(raw.operands[0], matrixStride)
+		if err != nil {
+			return Type{}, err
+		}
+
+		stride := element.Size
+		if arrayStride, ok := p.decorations[typeID][decorationArrayStride]; ok {
+			stride = arrayStride
+		}
+
+		element.Array = 0 // runtime array
+		if raw.op == opTypeArray && len(raw.operands) >= 2 {
+			element.Array = p.constants[raw.operands[1]]
+		}
+
+		element.Size = stride * element.Array
+		return element, nil
+	case opTypeStruct:
+		size := uint32(0)
+		for ind, memberTypeID := range raw.operands {
+			member, err := p.memberType(typeID, uint32(ind), memberTypeID)
+			if err != nil {
+				return Type{}, err
+			}
+
+			end := p.members[typeID][uint32(ind)][decorationOffset] + member.Size
+			if end > size {
+				size = end
+			}
+		}
+
+		return Type{Kind: KindStruct, Vector: 1, Columns: 1, Size: size}, nil
+	default:
+		return Type{}, fmt.Errorf("type %d (op %d) is not numeric", typeID, raw.op)
+	}
+}
+
+func sortVariables(vars []Variable) {
+	sort.Slice(vars, func(i, j int) bool {
+		return vars[i].Location < vars[j].Location
+	})
+}
+
+// literalString decode null-terminated UTF-8 string,
+// packed into words, and return count of used words
+func literalString(words []uint32) (string, int) {
+	buf := make([]byte, 0, len(words)*4)
+
+	for ind, word := range words {
+		for shift := 0; shift < 32; shift += 8 {
+			b := byte(word >> shift)
+			if b == 0 {
+				return string(buf), ind + 1
+			}
+
+			buf = append(buf, b)
+		}
+	}
+
+	return string(buf), len(words)
+}
+
+func optional(operands []uint32, ind int) uint32 {
+	if ind < len(operands) {
+		return operands[ind]
+	}
+
+	return 0
+}
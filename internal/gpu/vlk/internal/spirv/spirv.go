@@ -0,0 +1,171 @@
+// Package spirv is minimal SPIR-V parser, that read shader
+// interface (entry points, inputs, push constants and descriptors)
+// from compiled bytecode without GPU. It is not validator and not
+// disassembler, all instructions not related to interface are skipped
+package spirv
+
+import (
+	"encoding/binary"
+	"errors"
+	"fmt"
+)
+
+// Magic is first word of every SPIR-V module
+const Magic = 0x07230203
+
+const headerWords = 5
+
+var ErrInvalidModule = errors.New("invalid SPIR-V module")
+
+type (
+	// Module is reflected shader interface
+	Module struct {
+		Version     Version
+		EntryPoints []EntryPoint
+
+		// stage input and output variables with location,
+		// sorted by location. Built-ins is not included
+		Inputs  []Variable
+		Outputs []Variable
+
+		// push constants block, nil when shader not use it
+		PushConstants *Block
+
+		// all resources from descriptor sets,
+		// sorted by set and binding
+		Descriptors []Descriptor
+	}
+
+	Version struct {
+		Major uint8
+		Minor uint8
+	}
+
+	EntryPoint struct {
+		Name  string
+		Model ExecutionModel
+	}
+
+	Variable struct {
+		Name     string
+		Location uint32
+		Type     Type
+	}
+
+	Descriptor struct {
+		Name    string
+		Set     uint32
+		Binding uint32
+		Type    DescriptorType
+		Count   uint32 // array size, 1 for not arrays
+		Block   *Block // buffer layout for uniform/storage buffers
+	}
+
+	Block struct {
+		Name    string
+		Size    uint32 // size in bytes, including last member
+		Members []Member
+	}
+
+	Member struct {
+		Name   string
+		Offset uint32
+		Type   Type
+	}
+
+	// Type is numeric type of variable or block member
+	Type struct {
+		Kind    Kind
+		Width   uint32 // bits of one component
+		Vector  uint32 // components count (1 for scalars)
+		Columns uint32 // matrix columns (1 for not matrices)
+		Array   uint32 // array length (0 for not arrays)
+		Size    uint32 // size in bytes (with array and matrix strides)
+	}
+
+	Kind uint8
+
+	ExecutionModel uint32
+
+	DescriptorType uint8
+)
+
+const (
+	KindUnknown Kind = iota
+	KindFloat
+	KindInt
+	KindUint
+	KindBool
+	KindStruct
+)
+
+const (
+	ExecutionModelVertex                 ExecutionModel = 0
+	ExecutionModelTessellationControl    ExecutionModel = 1
+	ExecutionModelTessellationEvaluation ExecutionModel = 2
+	ExecutionModelGeometry               ExecutionModel = 3
+	ExecutionModelFragment               ExecutionModel = 4
+	ExecutionModelGLCompute              ExecutionModel = 5
+)
+
+const (
+	DescriptorUniformBuffer DescriptorType = iota
+	DescriptorStorageBuffer
+	DescriptorCombinedImageSampler
+	DescriptorSampledImage
+	DescriptorStorageImage
+	DescriptorSampler
+)
+
+func (v Version) String() string {
+	return fmt.Sprintf("%d.%d", v.Major, v.Minor)
+}
+
+func (m ExecutionModel) String() string {
+	switch m {
+	case ExecutionModelVertex:
+		return "vertex"
+	case ExecutionModelTessellationControl:
+		return "tessellation control"
+	case ExecutionModelTessellationEvaluation:
+		return "tessellation evaluation"
+	case ExecutionModelGeometry:
+		return "geometry"
+	case ExecutionModelFragment:
+		return "fragment"
+	case ExecutionModelGLCompute:
+		return "compute"
+	default:
+		return fmt.Sprintf("unknown(%d)", uint32(m))
+	}
+}
+
+// EntryPoint find entry point by name and execution model
+func (m *Module) EntryPoint(name string, model ExecutionModel) (EntryPoint, bool) {
+	for _, entry := range m.EntryPoints {
+		if entry.Name == name && entry.Model == model {
+			return entry, true
+		}
+	}
+
+	return EntryPoint{}, false
+}
+
+// Words convert bytecode into SPIR-V words, bytecode
+// should be not empty and aligned to word size
+func Words(byteCode []byte) ([]uint32, error) {
+	if len(byteCode) == 0 {
+		return nil, fmt.Errorf("%w: empty bytecode", ErrInvalidModule)
+	}
+
+	if len(byteCode)%4 != 0 {
+		return nil, fmt.Errorf("%w: bytecode size %d is not multiple of word size (4)", ErrInvalidModule, len(byteCode))
+	}
+
+	words := make([]uint32, len(byteCode)/4)
+	for i := range words {
+		words[i] = binary.LittleEndian.Uint32(byteCode[i*4:])
+	}
+
+	return words, nil
+}
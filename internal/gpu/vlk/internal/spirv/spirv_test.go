@@ -0,0 +1,278 @@
+package spirv
+
+import (
+	"encoding/binary"
+	"errors"
+	"os"
+	"testing"
+)
+
+func readShader(t *testing.T, name string) []byte {
+	t.Helper()
+
+	byteCode, err := os.ReadFile("../../shaders/" + name)
+	if err != nil {
+		t.Fatalf("failed read shader: %v", err)
+	}
+
+	return byteCode
+}
+
+func TestParse_EmbeddedShaders(t *testing.T) {
+	tests := []struct {
+		file    string
+		model   ExecutionModel
+		inputs  []Type
+		outputs int
+		push    uint32 // push constants block size
+	}{
+		{
+			file:    "rect.vert.spv",
+			model:   ExecutionModelVertex,
+			inputs:  []Type{{Kind: KindFloat, Width: 32, Vector: 2, Columns: 1, Size: 8}, {Kind: KindFloat, Width: 32, Vector: 3, Columns: 1, Size: 12}},
+			outputs: 1,
+		},
+		{file: "rect.frag.spv", model: ExecutionModelFragment, inputs: []Type{{Kind: KindFloat, Width: 32, Vector: 3, Columns: 1, Size: 12}}, outputs: 1},
+		{file: "triangle.vert.spv", model: ExecutionModelVertex, outputs: 1},
+		{
+			file:    "mesh.vert.spv",
+			model:   ExecutionModelVertex,
+			inputs:  []Type{{Kind: KindFloat, Width: 32, Vector: 2, Columns: 1, Size: 8}, {Kind: KindFloat, Width: 32, Vector: 3, Columns: 1, Size: 12}},
+			outputs: 1,
+			push:    64,
+		},
+		{file: "triangle.frag.spv", model: ExecutionModelFragment, inputs: []Type{{Kind: KindFloat, Width: 32, Vector: 3, Columns: 1, Size: 12}}, outputs: 1},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.file, func(t *testing.T) {
+			module, err := Parse(readShader(t, tt.file))
+			if err != nil {
+				t.Fatalf("failed parse: %v", err)
+			}
+
+			if module.Version != (Version{Major: 1, Minor: 0}) {
+				t.Errorf("version %s, want 1.0", module.Version)
+			}
+
+			if _, ok := module.EntryPoint("main", tt.model); !ok {
+				t.Errorf("entry point 'main' for %s stage not found in %v", tt.model, module.EntryPoints)
+			}
+
+			if len(module.Inputs) != len(tt.inputs) {
+				t.Fatalf("got %d inputs, want %d", len(module.Inputs), len(tt.inputs))
+			}
+
+			for ind, input := range module.Inputs {
+				if input.Location != uint32(ind) {
+					t.Errorf("input %d: location %d", ind, input.Location)
+				}
+
+				if input.Type != tt.inputs[ind] {
+					t.Errorf("input %d: type %+v, want %+v", ind, input.Type, tt.inputs[ind])
+				}
+			}
+
+			if len(module.Outputs) != tt.outputs {
+				t.Errorf("got %d outputs, want %d", len(module.Outputs), tt.outputs)
+			}
+
+			if len(module.Descriptors) != 0 {
+				t.Errorf("unexpected descriptors: %+v", module.Descriptors)
+			}
+
+			if tt.push == 0 && module.PushConstants != nil {
+				t.Errorf("unexpected push constants: %+v", module.PushConstants)
+			}
+
+			if tt.push > 0 && (module.PushConstants == nil || module.PushConstants.Size != tt.push) {
+				t.Errorf("push constants %+v, want block of %d bytes", module.PushConstants, tt.push)
+			}
+		})
+	}
+}
+
+func TestParse_Resources(t *testing.T) {
+	module, err := ParseWords(resourcesModule())
+	if err != nil {
+		t.Fatalf("failed parse: %v", err)
+	}
+
+	if module.PushConstants == nil {
+		t.Fatalf("push constants not found")
+	}
+
+	if module.PushConstants.Size != 20 || len(module.PushConstants.Members) != 2 {
+		t.Errorf("push constants: %+v", module.PushConstants)
+	}
+
+	if scale := module.PushConstants.Members[1]; scale.Name != "scale" || scale.Offset != 16 {
+		t.Errorf("push constants member: %+v", scale)
+	}
+
+	if len(module.Descriptors) != 2 {
+		t.Fatalf("got %d descriptors, want 2", len(module.Descriptors))
+	}
+
+	ubo := module.Descriptors[0]
+	if ubo.Name != "ubo" || ubo.Set != 0 || ubo.Binding != 1 || ubo.Type != DescriptorUniformBuffer || ubo.Count != 1 {
+		t.Errorf("ubo descriptor: %+v", ubo)
+	}
+
+	if ubo.Block == nil || ubo.Block.Size != 128 || ubo.Block.Members[1].Offset != 64 {
+		t.Errorf("ubo block: %+v", ubo.Block)
+	}
+
+	tex := module.Descriptors[1]
+	if tex.Set != 1 || tex.Binding != 0 || tex.Type != DescriptorCombinedImageSampler || tex.Count != 2 {
+		t.Errorf("texture descriptor: %+v", tex)
+	}
+}
+
+func TestParse_Invalid(t *testing.T) {
+	valid := readShader(t, "rect.vert.spv")
+
+	badMagic := append([]byte(nil), valid...)
+	binary.LittleEndian.PutUint32(badMagic, 0xdeadbeef)
+
+	brokenInstruction := append([]byte(nil), valid[:headerWords*4]...)
+	brokenInstruction = binary.LittleEndian.AppendUint32(brokenInstruction, 10<<16|opName)
+
+	tests := map[string][]byte{
+		"empty":              nil,
+		"not aligned":        valid[:len(valid)-1],
+		"only header":        valid[:8],
+		"bad magic":          badMagic,
+		"broken instruction": brokenInstruction,
+	}
+
+	for name, byteCode := range tests {
+		t.Run(name, func(t *testing.T) {
+			if _, err := Parse(byteCode); !errors.Is(err, ErrInvalidModule) {
+				t.Errorf("expected ErrInvalidModule, got %v", err)
+			}
+		})
+	}
+}
+
+// resourcesModule is fragment shader interface:
+//
+//	layout(push_constant) uniform PC { vec4 tint; float scale; } pc;
+//	layout(set = 0, binding = 1) uniform UBO { mat4 proj; mat4 view; } ubo;
+//	layout(set = 1, binding = 0) uniform sampler2D tex[2];
+//	layout(location = 0) in vec2 uv;
+func resourcesModule() []uint32 {
+	a := &assembler{}
+	a.words = []uint32{Magic, 0x00010000, 0, 30, 0}
+
+	a.op(opEntryPoint, append([]uint32{uint32(ExecutionModelFragment), 1}, literal("main")...)...)
+	a.op(opName, append([]uint32{7}, literal("pc")...)...)
+	a.op(opMemberName, append([]uint32{5, 1}, literal("scale")...)...)
+	a.op(opName, append([]uint32{10}, literal("ubo")...)...)
+
+	a.op(opDecorate, 5, decorationBlock)
+	a.op(opMemberDecorate, 5, 0, decorationOffset, 0)
+	a.op(opMemberDecorate, 5, 1, decorationOffset, 16)
+	a.op(opDecorate, 8, decorationBlock)
+	a.op(opMemberDecorate, 8, 0, decorationOffset, 0)
+	a.op(opMemberDecorate, 8, 0, decorationMatrixStride, 16)
+	a.op(opMemberDecorate, 8, 1, decorationOffset, 64)
+	a.op(opMemberDecorate, 8, 1, decorationMatrixStride, 16)
+	a.op(opDecorate, 10, decorationDescriptorSet, 0)
+	a.op(opDecorate, 10, decorationBinding, 1)
+	a.op(opDecorate, 18, decorationDescriptorSet, 1)
+	a.op(opDecorate, 18, decorationBinding, 0)
+	a.op(opDecorate, 21, decorationLocation, 0)
+
+	a.op(opTypeFloat, 2, 32)
+	a.op(opTypeVector, 3, 2, 4)
+	a.op(opTypeMatrix, 4, 3, 4)
+	a.op(opTypeStruct, 5, 3, 2)
+	a.op(opTypePointer, 6, storagePushConstant, 5)
+	a.op(opVariable, 6, 7, storagePushConstant)
+	a.op(opTypeStruct, 8, 4, 4)
+	a.op(opTypePointer, 9, storageUniform, 8)
+	a.op(opVariable, 9, 10, storageUniform)
+	a.op(opTypeImage, 11, 2, 1, 0, 0, 0, 1, 0)
+	a.op(opTypeSampledImage, 12, 11)
+	a.op(opTypeInt, 13, 32, 0)
+	a.op(opConstant, 13, 14, 2)
+	a.op(opTypeArray, 15, 12, 14)
+	a.op(opTypePointer, 16, storageUniformConstant, 15)
+	a.op(opTypeVector, 17, 2, 2)
+	a.op(opVariable, 16, 18, storageUniformConstant)
+	a.op(opTypePointer, 20, storageInput, 17)
+	a.op(opVariable, 20, 21, storageInput)
+
+	return a.words
+}
+
+type assembler struct {
+	words []uint32
+}
+
+func (a *assembler) op(opcode uint32, operands ...uint32) {
+	a.words = append(a.words, uint32(len(operands)+1)<<16|opcode)
+	a.words = append(a.words, operands...)
+}
+
+func literal(s string) []uint32 {
+	data := append([]byte(s), 0)
+	for len(data)%4 != 0 {
+		data = append(data, 0)
+	}
+
+	words := make([]uint32, len(data)/4)
+	for i := range words {
+		words[i] = binary.LittleEndian.Uint32(data[i*4:])
+	}
+
+	return words
+}
+
+func TestValidate(t *testing.T) {
+	vert := readShader(t, "rect.vert.spv")
+	v10 := Version{Major: 1, Minor: 0}
+
+	if _, err := Validate(vert, "main", ExecutionModelVertex, v10); err != nil {
+		t.Fatalf("valid shader: %v", err)
+	}
+
+	newer := append([]byte(nil), vert...)
+	binary.LittleEndian.PutUint32(newer[4:], 0x00010300)
+
+	swapped := make([]byte, len(vert))
+	for i := 0; i < len(vert); i += 4 {
+		binary.BigEndian.PutUint32(swapped[i:], binary.LittleEndian.Uint32(vert[i:]))
+	}
+
+	if _, err := Parse(swapped); err != nil {
+		t.Fatalf("byte-swapped module should be parsed: %v", err)
+	}
+
+	tests := map[string]struct {
+		byteCode []byte
+		entry    string
+		model    ExecutionModel
+		max      Version
+	}{
+		"not aligned":     {byteCode: vert[:len(vert)-2], entry: "main", model: ExecutionModelVertex, max: v10},
+		"newer version":   {byteCode: newer, entry: "main", model: ExecutionModelVertex, max: v10},
+		"wrong stage":     {byteCode: vert, entry: "main", model: ExecutionModelFragment, max: v10},
+		"no entry point":  {byteCode: vert, entry: "start", model: ExecutionModelVertex, max: v10},
+		"empty byte code": {byteCode: nil, entry: "main", model: ExecutionModelVertex, max: v10},
+		"byte-swapped":    {byteCode: swapped, entry: "main", model: ExecutionModelVertex, max: v10},
+	}
+
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			if _, err := Validate(tt.byteCode, tt.entry, tt.model, tt.max); !errors.Is(err, ErrInvalidModule) {
+				t.Errorf("expected ErrInvalidModule, got %v", err)
+			}
+		})
+	}
+
+	if _, err := Validate(newer, "main", ExecutionModelVertex, MaxVersion(1<<22|1<<12)); err != nil {
+		t.Errorf("SPIR-V 1.3 should be valid for vulkan 1.1: %v", err)
+	}
+}
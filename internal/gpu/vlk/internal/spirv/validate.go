@@ -0,0 +1,70 @@
+package spirv
+
+import "fmt"
+
+// Less report that version v is older than other
+func (v Version) Less(other Version) bool {
+	if v.Major != other.Major {
+		return v.Major < other.Major
+	}
+
+	return v.Minor < other.Minor
+}
+
+// MaxVersion return latest SPIR-V version, that must be
+// supported by device with given vulkan api version
+// (encoded with vulkan.MakeVersion)
+func MaxVersion(vulkanAPIVersion uint32) Version {
+	major, minor := vulkanAPIVersion>>22, (vulkanAPIVersion>>12)&0x3ff
+
+	switch {
+	case major > 1 || minor >= 3:
+		return Version{Major: 1, Minor: 6}
+	case minor == 2:
+		return Version{Major: 1, Minor: 5}
+	case minor == 1:
+		return Version{Major: 1, Minor: 3}
+	default:
+		return Version{Major: 1, Minor: 0}
+	}
+}
+
+// Validate check that bytecode is SPIR-V module, that can be
+// loaded by device: magic number, word alignment, version not
+// above maxVersion and entry point for expected stage
+func Validate(byteCode []byte, entryPoint string, model ExecutionModel, maxVersion Version) (*Module, error) {
+	words, err := Words(byteCode)
+	if err != nil {
+		return nil, err
+	}
+
+	// bytecode is passed to vkCreateShaderModule as is, so
+	// byte-swapped (big-endian) modules can be parsed, but not loaded
+	if words[0] != Magic {
+		return nil, fmt.Errorf("%w: bad magic number 0x%08x (module should be little-endian)", ErrInvalidModule, words[0])
+	}
+
+	module, err := ParseWords(words)
+	if err != nil {
+		return nil, err
+	}
+
+	if module.Version.Major != 1 || maxVersion.Less(module.Version) {
+		return nil, fmt.Errorf("%w: SPIR-V version %s is not supported by device (max %s)",
+			ErrInvalidModule, module.Version, maxVersion,
+		)
+	}
+
+	if _, ok := module.EntryPoint(entryPoint, model); !ok {
+		found := make([]string, 0, len(module.EntryPoints))
+		for _, entry := range module.EntryPoints {
+			found = append(found, fmt.Sprintf("'%s' (%s)", entry.Name, entry.Model))
+		}
+
+		return nil, fmt.Errorf("%w: entry point '%s' for %s stage not found, module has %v",
+			ErrInvalidModule, entryPoint, model, found,
+		)
+	}
+
+	return module, nil
+}
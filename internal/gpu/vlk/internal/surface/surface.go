@@ -2,7 +2,7 @@ package surface
 
 import (
 	"fmt"
-	"log"
+	"log/slog"
 
 	"github.com/vulkan-go/vulkan"
 
@@ -11,26 +11,28 @@ import (
 )
 
 type Surface struct {
-	inst *instance.Instance
+	logger *slog.Logger
+	inst   *instance.Instance
 
 	ref vulkan.Surface
 }
 
-func NewSurface(inst *instance.Instance, wm arch.WindowManager) *Surface {
+func NewSurface(logger *slog.Logger, inst *instance.Instance, wm arch.WindowManager) *Surface {
 	surface, err := wm.CreateSurface(inst.Ref())
 	if err != nil {
 		panic(fmt.Errorf("failed create vulkan surface: %w", err))
 	}
 
 	return &Surface{
-		inst: inst,
-		ref:  surface,
+		logger: logger,
+		inst:   inst,
+		ref:    surface,
 	}
 }
 
 func (s *Surface) Free() {
 	vulkan.DestroySurface(s.inst.Ref(), s.ref, nil)
-	log.Printf("vk: freed: surface\n")
+	s.logger.Debug("freed: surface")
 }
 
 func (s *Surface) Ref() vulkan.Surface {
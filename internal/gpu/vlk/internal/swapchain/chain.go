@@ -1,10 +1,12 @@
 package swapchain
 
 import (
-	"log"
+	"fmt"
+	"log/slog"
 
 	"github.com/vulkan-go/vulkan"
 
+	"github.com/go-glx/vgl/internal/gpu/vlk/internal/debugutils"
 	"github.com/go-glx/vgl/internal/gpu/vlk/internal/logical"
 	"github.com/go-glx/vgl/internal/gpu/vlk/internal/physical"
 	"github.com/go-glx/vgl/internal/gpu/vlk/internal/renderpass"
@@ -18,11 +20,12 @@ type Chain struct {
 	views     []vulkan.ImageView
 	buffers   []vulkan.Framebuffer
 
-	ld *logical.Device
+	logger *slog.Logger
+	ld     *logical.Device
 }
 
-func NewChain(width, height uint32, pd *physical.Device, ld *logical.Device, surface *surface.Surface, mainRenderPass *renderpass.Pass, mobileFriendly bool) *Chain {
-	props := newProps(width, height, pd, mobileFriendly)
+func NewChain(logger *slog.Logger, names *debugutils.Names, width, height uint32, pd *physical.Device, ld *logical.Device, surface *surface.Surface, mainRenderPass *renderpass.Pass, presentModes []vulkan.PresentMode, readback bool) *Chain {
+	props := newProps(width, height, pd, presentModes, readback)
 	sharingMode := deviceSharingMode(pd)
 	swapChain := newSwapChain(pd, ld, surface, props, sharingMode)
 
@@ -30,7 +33,16 @@ func NewChain(width, height uint32, pd *physical.Device, ld *logical.Device, sur
 	views := createViews(images, ld, props)
 	buffers := createFrameBuffers(ld, mainRenderPass.Ref(), props, views)
 
-	log.Printf("vk: swapchain created, images=%d, props=(%s)\n", len(images), props.String())
+	for ind := range images {
+		names.Image(images[ind], fmt.Sprintf("swapchain.image.%d", ind))
+		names.ImageView(views[ind], fmt.Sprintf("swapchain.view.%d", ind))
+		names.FrameBuffer(buffers[ind], fmt.Sprintf("swapchain.framebuffer.%d", ind))
+	}
+
+	logger.Debug("swapchain created",
+		slog.Int("images", len(images)),
+		slog.String("props", props.String()),
+	)
 
 	return &Chain{
 		props:     props,
@@ -39,7 +51,8 @@ func NewChain(width, height uint32, pd *physical.Device, ld *logical.Device, sur
 		views:     views,
 		buffers:   buffers,
 
-		ld: ld,
+		logger: logger,
+		ld:     ld,
 	}
 }
 
@@ -53,7 +66,7 @@ func (c *Chain) Free() {
 	}
 
 	vulkan.DestroySwapchain(c.ld.Ref(), c.swapChain, nil)
-	log.Printf("vk: freed: swapchain\n")
+	c.logger.Debug("freed: swapchain")
 }
 
 func (c *Chain) Ref() vulkan.Swapchain {
@@ -64,6 +77,10 @@ func (c *Chain) Props() ChainProps {
 	return c.props
 }
 
+func (c *Chain) Image(index int) vulkan.Image {
+	return c.images[index]
+}
+
 func (c *Chain) FrameBuffer(index int) vulkan.Framebuffer {
 	return c.buffers[index]
 }
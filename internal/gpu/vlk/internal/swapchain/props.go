@@ -5,7 +5,9 @@ import (
 
 	"github.com/vulkan-go/vulkan"
 
+	"github.com/go-glx/vgl/internal/gpu/vlk/internal/def"
 	"github.com/go-glx/vgl/internal/gpu/vlk/internal/physical"
+	"github.com/go-glx/vgl/internal/gpu/vlk/internal/vkconv"
 )
 
 type ChainProps struct {
@@ -14,25 +16,41 @@ type ChainProps struct {
 	BufferSize      vulkan.Extent2D
 	PresentMode     vulkan.PresentMode
 	BuffersCount    uint32
+	ImageUsage      vulkan.ImageUsageFlags
+
+	// OutputTransform is conversion of linear colors into
+	// images color space, that shaders should do
+	OutputTransform def.OutputTransform
+	HDR             bool
 }
 
-func newProps(width, height uint32, pd *physical.Device, mobileFriendly bool) ChainProps {
+func newProps(width, height uint32, pd *physical.Device, presentModes []vulkan.PresentMode, readback bool) ChainProps {
 	gpuProps := pd.PrimaryGPU().SurfaceProps
-	richColorFormat := gpuProps.RichColorSpaceFormat()
+	surfaceFormat, _ := gpuProps.SurfaceFormat()
+
+	usage := vulkan.ImageUsageFlags(vulkan.ImageUsageColorAttachmentBit)
+	if readback {
+		// images will be copied to CPU memory after each frame
+		usage |= vulkan.ImageUsageFlags(vulkan.ImageUsageTransferSrcBit)
+	}
 
 	return ChainProps{
-		ImageFormat:     richColorFormat.Format,
-		ImageColorSpace: richColorFormat.ColorSpace,
+		ImageFormat:     surfaceFormat.Format,
+		ImageColorSpace: surfaceFormat.ColorSpace,
 		BufferSize:      gpuProps.ChooseSwapExtent(width, height),
-		PresentMode:     gpuProps.BestPresentMode(mobileFriendly),
+		PresentMode:     gpuProps.PresentMode(presentModes),
 		BuffersCount:    gpuProps.ConcurrentBuffersCount(),
+		ImageUsage:      usage,
+		OutputTransform: surfaceFormat.Transform,
+		HDR:             surfaceFormat.HDR,
 	}
 }
 
 func (p *ChainProps) String() string {
-	return fmt.Sprintf("format=%s, colorSpace=%s, buffersCount=%s, bufferSize=%s, presentMode=%s",
+	return fmt.Sprintf("format=%s, colorSpace=%s, hdr=%t, buffersCount=%s, bufferSize=%s, presentMode=%s",
 		p.formatString(),
 		p.colorSpaceString(),
+		p.HDR,
 		p.buffersCountString(),
 		p.bufferSizeString(),
 		p.presentModeString(),
@@ -40,19 +58,11 @@ func (p *ChainProps) String() string {
 }
 
 func (p *ChainProps) formatString() string {
-	if name, ok := propsImageFormats[p.ImageFormat]; ok {
-		return name
-	}
-
-	return "unknown"
+	return vkconv.FormatName(p.ImageFormat)
 }
 
 func (p *ChainProps) colorSpaceString() string {
-	if name, ok := propsColorSpaces[p.ImageColorSpace]; ok {
-		return name
-	}
-
-	return "unknown"
+	return vkconv.ColorSpaceName(p.ImageColorSpace)
 }
 
 func (p *ChainProps) bufferSizeString() string {
@@ -60,11 +70,7 @@ func (p *ChainProps) bufferSizeString() string {
 }
 
 func (p *ChainProps) presentModeString() string {
-	if name, ok := propsPresentModes[p.PresentMode]; ok {
-		return name
-	}
-
-	return "unknown"
+	return vkconv.PresentModeName(p.PresentMode)
 }
 
 func (p *ChainProps) buffersCountString() string {
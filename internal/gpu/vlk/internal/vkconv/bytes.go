@@ -2,18 +2,17 @@ package vkconv
 
 import (
 	"unsafe"
-
-	"github.com/vulkan-go/vulkan"
 )
 
-type sliceHeader struct {
-	Data uintptr
-	Len  int
-	Cap  int
-}
-
+// TransformByteCode copy SPIR-V bytecode into words slice. Last
+// not full word is padded with zeros, so bytecode should be
+// validated for word alignment before passing it to vulkan
 func TransformByteCode(data []byte) []uint32 {
-	buf := make([]uint32, len(data)/4)
-	vulkan.Memcopy(unsafe.Pointer((*sliceHeader)(unsafe.Pointer(&buf)).Data), data)
+	buf := make([]uint32, (len(data)+3)/4)
+	if len(buf) == 0 {
+		return buf
+	}
+
+	copy(unsafe.Slice((*byte)(unsafe.Pointer(&buf[0])), len(buf)*4), data)
 	return buf
 }
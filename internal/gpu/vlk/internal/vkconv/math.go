@@ -1,5 +1,7 @@
 package vkconv
 
+import "math"
+
 func ClampUint(n, min, max uint32) uint32 {
 	if n <= min {
 		return min
@@ -11,3 +13,38 @@ func ClampUint(n, min, max uint32) uint32 {
 
 	return n
 }
+
+// LinearToSRGB encode linear color channel [0..1] into
+// sRGB, same as GPU does for *_SRGB image formats
+func LinearToSRGB(c float32) float32 {
+	if c <= 0.0031308 {
+		return c * 12.92
+	}
+
+	return float32(1.055*math.Pow(float64(c), 1/2.4) - 0.055)
+}
+
+// LinearToPQ encode absolute luminance in nits
+// with SMPTE ST 2084 (PQ) curve, used in HDR10
+func LinearToPQ(nits float32) float32 {
+	const (
+		m1 = 0.1593017578125
+		m2 = 78.84375
+		c1 = 0.8359375
+		c2 = 18.8515625
+		c3 = 18.6875
+	)
+
+	ym := math.Pow(math.Max(float64(nits)/10000, 0), m1)
+	return float32(math.Pow((c1+c2*ym)/(1+c3*ym), m2))
+}
+
+// BT709ToBT2020 convert linear color from BT.709 (sRGB)
+// primaries into BT.2020 primaries
+func BT709ToBT2020(c [3]float32) [3]float32 {
+	return [3]float32{
+		0.6274*c[0] + 0.3293*c[1] + 0.0433*c[2],
+		0.0691*c[0] + 0.9195*c[1] + 0.0114*c[2],
+		0.0164*c[0] + 0.0880*c[1] + 0.8956*c[2],
+	}
+}
@@ -1,10 +1,10 @@
-package swapchain
+package vkconv
 
 import (
 	"github.com/vulkan-go/vulkan"
 )
 
-var propsImageFormats = map[vulkan.Format]string{
+var imageFormats = map[vulkan.Format]string{
 	vulkan.FormatR4g4UnormPack8:                       "R4g4UnormPack8",
 	vulkan.FormatR4g4b4a4UnormPack16:                  "R4g4b4a4UnormPack16",
 	vulkan.FormatB4g4r4a4UnormPack16:                  "B4g4r4a4UnormPack16",
@@ -233,7 +233,7 @@ var propsImageFormats = map[vulkan.Format]string{
 	vulkan.FormatPvrtc24bppSrgbBlockImg:               "Pvrtc24bppSrgbBlockImg",
 }
 
-var propsColorSpaces = map[vulkan.ColorSpace]string{
+var colorSpaces = map[vulkan.ColorSpace]string{
 	vulkan.ColorSpaceSrgbNonlinear:         "SrgbNonlinear",
 	vulkan.ColorSpaceDisplayP3Nonlinear:    "DisplayP3Nonlinear",
 	vulkan.ColorSpaceExtendedSrgbLinear:    "ExtendedSrgbLinear",
@@ -251,7 +251,7 @@ var propsColorSpaces = map[vulkan.ColorSpace]string{
 	vulkan.ColorSpaceExtendedSrgbNonlinear: "ExtendedSrgbNonlinear",
 }
 
-var propsPresentModes = map[vulkan.PresentMode]string{
+var presentModes = map[vulkan.PresentMode]string{
 	vulkan.PresentModeImmediate:               "Immediate",
 	vulkan.PresentModeMailbox:                 "Mailbox",
 	vulkan.PresentModeFifo:                    "Fifo",
@@ -259,3 +259,27 @@ var propsPresentModes = map[vulkan.PresentMode]string{
 	vulkan.PresentModeSharedDemandRefresh:     "SharedDemandRefresh",
 	vulkan.PresentModeSharedContinuousRefresh: "SharedContinuousRefresh",
 }
+
+func FormatName(format vulkan.Format) string {
+	if name, ok := imageFormats[format]; ok {
+		return name
+	}
+
+	return "unknown"
+}
+
+func ColorSpaceName(colorSpace vulkan.ColorSpace) string {
+	if name, ok := colorSpaces[colorSpace]; ok {
+		return name
+	}
+
+	return "unknown"
+}
+
+func PresentModeName(mode vulkan.PresentMode) string {
+	if name, ok := presentModes[mode]; ok {
+		return name
+	}
+
+	return "unknown"
+}
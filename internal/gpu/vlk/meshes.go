@@ -0,0 +1,247 @@
+package vlk
+
+import (
+	"encoding/binary"
+	"fmt"
+	"log/slog"
+	"math"
+	"strings"
+
+	"github.com/vulkan-go/vulkan"
+
+	"github.com/go-glx/vgl/driver"
+	"github.com/go-glx/vgl/glm"
+	"github.com/go-glx/vgl/internal/gpu/vlk/internal/buffer"
+	"github.com/go-glx/vgl/internal/gpu/vlk/internal/command"
+	"github.com/go-glx/vgl/internal/gpu/vlk/internal/debugutils"
+	"github.com/go-glx/vgl/internal/gpu/vlk/internal/logical"
+	"github.com/go-glx/vgl/internal/gpu/vlk/internal/memory"
+	"github.com/go-glx/vgl/internal/gpu/vlk/internal/pipeline"
+)
+
+type (
+	// meshStore keep static meshes in device local memory
+	meshStore struct {
+		logger   *slog.Logger
+		names    *debugutils.Names
+		alloc    *memory.Allocator
+		ld       *logical.Device
+		transfer *command.Transfer
+
+		items   map[driver.MeshID]*staticMesh
+		last    driver.MeshID
+		retired []retiredMesh
+		frames  int
+	}
+
+	staticMesh struct {
+		vertexes    *buffer.Device
+		indexes     *buffer.Device // nil, when mesh not indexed
+		vertexCount uint32
+		indexCount  uint32
+
+		// pipeline vertex input, from mesh layout
+		pipelineName string
+		bindings     []vulkan.VertexInputBindingDescription
+		attributes   []vulkan.VertexInputAttributeDescription
+	}
+
+	// retiredMesh is freed mesh, that still can be used
+	// by GPU in frames in flight
+	retiredMesh struct {
+		mesh   *staticMesh
+		frames int // frame starts left before free
+	}
+)
+
+func newMeshStore(
+	logger *slog.Logger,
+	names *debugutils.Names,
+	alloc *memory.Allocator,
+	ld *logical.Device,
+	transfer *command.Transfer,
+	framesInFlight int,
+) *meshStore {
+	return &meshStore{
+		logger:   logger,
+		names:    names,
+		alloc:    alloc,
+		ld:       ld,
+		transfer: transfer,
+		items:    make(map[driver.MeshID]*staticMesh),
+		frames:   framesInFlight,
+	}
+}
+
+func (s *meshStore) free() {
+	for _, mesh := range s.items {
+		mesh.free()
+	}
+
+	for _, retired := range s.retired {
+		retired.mesh.free()
+	}
+
+	s.logger.Debug("freed: meshes")
+}
+
+func (s *meshStore) create(vertices []byte, indices []uint32, layout driver.VertexLayout) (driver.MeshID, error) {
+	if err := driver.ValidateMesh(vertices, indices, layout); err != nil {
+		return 0, err
+	}
+
+	// pipeline use only attributes of mesh shader
+	shaderLayout := driver.VertexLayout{Stride: layout.Stride}
+	for _, attr := range layout.Attributes {
+		if attr.Location == driver.MeshLocationPosition || attr.Location == driver.MeshLocationColor {
+			shaderLayout.Attributes = append(shaderLayout.Attributes, attr)
+		}
+	}
+
+	bindings, attributes, err := customVertexInput(shaderLayout)
+	if err != nil {
+		return 0, fmt.Errorf("%w: %s", driver.ErrInvalidMesh, err)
+	}
+
+	s.last++
+	id := s.last
+
+	mesh := &staticMesh{
+		vertexes:     buffer.NewDevice(s.alloc, s.ld, s.transfer, vertices, vulkan.BufferUsageVertexBufferBit),
+		vertexCount:  uint32(len(vertices)) / layout.Stride,
+		indexCount:   uint32(len(indices)),
+		pipelineName: meshPipelineName(shaderLayout),
+		bindings:     bindings,
+		attributes:   attributes,
+	}
+	s.names.Buffer(mesh.vertexes.Ref(), fmt.Sprintf("mesh.%d.vertexes", id))
+
+	if len(indices) > 0 {
+		data := make([]byte, 0, len(indices)*4)
+		for _, index := range indices {
+			data = binary.LittleEndian.AppendUint32(data, index)
+		}
+
+		mesh.indexes = buffer.NewDevice(s.alloc, s.ld, s.transfer, data, vulkan.BufferUsageIndexBufferBit)
+		s.names.Buffer(mesh.indexes.Ref(), fmt.Sprintf("mesh.%d.indexes", id))
+	}
+
+	s.items[id] = mesh
+	s.logger.Debug("mesh uploaded",
+		slog.Int("id", int(id)),
+		slog.Int("vertices", int(mesh.vertexCount)),
+		slog.Int("indices", int(mesh.indexCount)),
+	)
+
+	return id, nil
+}
+
+func (s *meshStore) get(id driver.MeshID) (*staticMesh, bool) {
+	mesh, ok := s.items[id]
+	return mesh, ok
+}
+
+// release remove mesh from store, mesh memory will be freed
+// after all frames in flight is done (see begin)
+func (s *meshStore) release(id driver.MeshID) {
+	mesh, ok := s.items[id]
+	if !ok {
+		return
+	}
+
+	delete(s.items, id)
+	s.retired = append(s.retired, retiredMesh{mesh: mesh, frames: s.frames})
+}
+
+// begin should be called on every frame start, after
+// frame fence is waited. Retired meshes is freed, when
+// fences of all frames in flight is waited after release
+func (s *meshStore) begin() {
+	alive := s.retired[:0]
+
+	for _, retired := range s.retired {
+		retired.frames--
+		if retired.frames > 0 {
+			alive = append(alive, retired)
+			continue
+		}
+
+		retired.mesh.free()
+	}
+
+	s.retired = alive
+}
+
+func (m *staticMesh) free() {
+	m.vertexes.Free()
+
+	if m.indexes != nil {
+		m.indexes.Free()
+	}
+}
+
+// meshPipelineName is unique pipeline name for mesh vertex layout,
+// meshes with same layout share one pipeline
+func meshPipelineName(layout driver.VertexLayout) string {
+	var name strings.Builder
+	name.WriteString(fmt.Sprintf("%s/%d", buildInShaderMesh, layout.Stride))
+
+	for _, attr := range layout.Attributes {
+		name.WriteString(fmt.Sprintf("/%d:%d:%d", attr.Location, attr.Format, attr.Offset))
+	}
+
+	return name.String()
+}
+
+// flushMesh record one draw call of static mesh,
+// all not flushed rects should be drawn before it
+func (vlk *VLK) flushMesh(id driver.MeshID, transform glm.Mat4) {
+	frames := vlk.cont.frameManager()
+	if !frames.Available() {
+		return
+	}
+
+	mesh, ok := vlk.cont.meshes().get(id)
+	if !ok {
+		vlk.cont.logger.Error("failed draw mesh", slog.Int("mesh", int(id)), slog.String("err", "mesh not found"))
+		return
+	}
+
+	sh, err := vlk.cont.shaderManager().ShaderByID(buildInShaderMesh)
+	if err != nil {
+		vlk.cont.logger.Error("failed draw mesh", slog.Any("err", err))
+		return
+	}
+
+	meta := sh.Meta()
+	opts := append(vlk.shaderPipelineOpts(sh), pipeline.WithVertexInput(mesh.bindings, mesh.attributes))
+	pipe := vlk.cont.pipelineFactory().Pipeline(mesh.pipelineName, opts...)
+	layout := vlk.cont.pipelineFactory().Layout(vlk.shaderLayout(meta))
+
+	matrix := transform.Floats()
+	uniforms := make([]byte, 0, len(matrix)*4)
+	for _, value := range matrix {
+		uniforms = binary.LittleEndian.AppendUint32(uniforms, math.Float32bits(value))
+	}
+
+	frames.FrameApplyCommands(func(_ uint32, cb vulkan.CommandBuffer) {
+		vlk.bindPipeline(cb, pipe)
+		pipeline.PushConstants(cb, layout, meta.PushConstants(), 0, uniforms)
+
+		vulkan.CmdBindVertexBuffers(cb, 0, 1,
+			[]vulkan.Buffer{mesh.vertexes.Ref()},
+			[]vulkan.DeviceSize{0},
+		)
+
+		if mesh.indexes != nil {
+			vulkan.CmdBindIndexBuffer(cb, mesh.indexes.Ref(), 0, vulkan.IndexTypeUint32)
+			vulkan.CmdDrawIndexed(cb, mesh.indexCount, 1, 0, 0, 0)
+		} else {
+			vulkan.CmdDraw(cb, mesh.vertexCount, 1, 0, 0)
+		}
+
+		vlk.frameStats.DrawCalls++
+		vlk.frameStats.Vertices += mesh.vertexCount
+		vlk.frameStats.Batches++
+	})
+}
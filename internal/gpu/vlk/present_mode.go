@@ -0,0 +1,36 @@
+package vlk
+
+import (
+	"github.com/vulkan-go/vulkan"
+
+	"github.com/go-glx/vgl/config"
+)
+
+var presentModes = map[config.PresentMode]vulkan.PresentMode{
+	config.PresentModeFIFO:        vulkan.PresentModeFifo,
+	config.PresentModeMailbox:     vulkan.PresentModeMailbox,
+	config.PresentModeImmediate:   vulkan.PresentModeImmediate,
+	config.PresentModeFIFORelaxed: vulkan.PresentModeFifoRelaxed,
+}
+
+func toVulkanPresentModes(preferred []config.PresentMode) []vulkan.PresentMode {
+	modes := make([]vulkan.PresentMode, 0, len(preferred))
+
+	for _, mode := range preferred {
+		if vkMode, ok := presentModes[mode]; ok {
+			modes = append(modes, vkMode)
+		}
+	}
+
+	return modes
+}
+
+func presentModeName(vkMode vulkan.PresentMode) string {
+	for mode, known := range presentModes {
+		if known == vkMode {
+			return mode.String()
+		}
+	}
+
+	return "unknown"
+}
@@ -0,0 +1,94 @@
+package vlk
+
+import (
+	"encoding/binary"
+	"log/slog"
+
+	"github.com/vulkan-go/vulkan"
+
+	"github.com/go-glx/vgl/glm"
+)
+
+const (
+	rectSizeVertex = glm.SizeOfVec2 + glm.SizeOfVec3
+	rectVertexes   = 4
+	rectIndexes    = 6
+)
+
+// alignment of data in frame ring buffer
+const (
+	streamAlignVertex = 16
+	streamAlignIndex  = 4
+)
+
+var rectIndexOrder = [rectIndexes]uint32{0, 1, 2, 2, 3, 0}
+
+// rectBatch collect rects data on CPU side, all collected
+// rects will be drawn with one draw call on flush
+type rectBatch struct {
+	vertexes []byte
+	indexes  []byte
+	count    int
+}
+
+func (b *rectBatch) add(vertexPos [4]glm.Vec2, vertexColor [4]glm.Vec3) {
+	first := uint32(b.count * rectVertexes)
+
+	for i := 0; i < rectVertexes; i++ {
+		b.vertexes = append(b.vertexes, vertexPos[i].Data()...)
+		b.vertexes = append(b.vertexes, vertexColor[i].Data()...)
+	}
+
+	for _, index := range rectIndexOrder {
+		b.indexes = binary.LittleEndian.AppendUint32(b.indexes, first+index)
+	}
+
+	b.count++
+}
+
+func (b *rectBatch) reset() {
+	b.vertexes = b.vertexes[:0]
+	b.indexes = b.indexes[:0]
+	b.count = 0
+}
+
+// flushRects will copy all collected rects into current
+// frame buffers and record one indexed draw call
+func (vlk *VLK) flushRects() {
+	if vlk.rects.count == 0 {
+		return
+	}
+
+	defer vlk.rects.reset()
+
+	frames := vlk.cont.frameManager()
+	if !frames.Available() {
+		return
+	}
+
+	rect, err := vlk.cont.shaderManager().ShaderByID(buildInShaderRect)
+	if err != nil {
+		vlk.cont.logger.Error("failed draw rects", slog.Any("err", err))
+		return
+	}
+
+	pipe := vlk.shaderPipeline(rect)
+
+	vertexes, vertexesOffset := vlk.cont.frameRing().Write(frames.FrameID(), vlk.rects.vertexes, streamAlignVertex)
+	indexes, indexesOffset := vlk.cont.frameRing().Write(frames.FrameID(), vlk.rects.indexes, streamAlignIndex)
+
+	frames.FrameApplyCommands(func(_ uint32, cb vulkan.CommandBuffer) {
+		vlk.bindPipeline(cb, pipe)
+
+		vulkan.CmdBindVertexBuffers(cb, 0, 1,
+			[]vulkan.Buffer{vertexes.Ref()},
+			[]vulkan.DeviceSize{vulkan.DeviceSize(vertexesOffset)},
+		)
+		vulkan.CmdBindIndexBuffer(cb, indexes.Ref(), vulkan.DeviceSize(indexesOffset), vulkan.IndexTypeUint32)
+		vulkan.CmdDrawIndexed(cb, uint32(vlk.rects.count*rectIndexes), 1, 0, 0, 0)
+
+		vlk.frameStats.DrawCalls++
+		vlk.frameStats.Vertices += uint32(vlk.rects.count * rectVertexes)
+		vlk.frameStats.Batches++
+	})
+}
@@ -0,0 +1,70 @@
+package vlk
+
+import (
+	"fmt"
+	"log/slog"
+
+	"github.com/vulkan-go/vulkan"
+
+	"github.com/go-glx/vgl/arch"
+	"github.com/go-glx/vgl/config"
+	"github.com/go-glx/vgl/internal/gpu/vlk/internal/instance"
+	"github.com/go-glx/vgl/internal/gpu/vlk/internal/physical"
+	"github.com/go-glx/vgl/internal/gpu/vlk/internal/surface"
+)
+
+type (
+	// CapabilityReport describe vulkan loader and all GPU's
+	// with checks, that used for choosing GPU for window
+	CapabilityReport struct {
+		Instance InstanceReport `json:"instance"`
+		GPUs     []GPUReport    `json:"gpus"`
+	}
+
+	InstanceReport struct {
+		Extensions       []string `json:"extensions"`        // available in vulkan loader
+		WindowExtensions []string `json:"window_extensions"` // required by window manager
+	}
+
+	GPUReport = physical.Report
+)
+
+// NewCapabilityReport create vulkan instance and window surface,
+// and assemble all GPU's, like it done in renderer initialization.
+// Nothing is rendered, so hidden window can be used
+func NewCapabilityReport(wm arch.WindowManager, cfg *config.Config) *CapabilityReport {
+	logger := cfg.Logger().With(slog.String("driver", "vulkan"))
+
+	wm.InitVulkanProcAddr()
+	if err := vulkan.Init(); err != nil {
+		panic(fmt.Errorf("failed init vulkan: %w", err))
+	}
+
+	inst := instance.NewInstance(
+		instance.NewCreateOptions(
+			logger.With(slog.String("module", "instance")),
+			wm.AppName(),
+			wm.EngineName(),
+			wm.GetRequiredInstanceExtensions(),
+			false,
+			cfg.HasHDR(),
+		),
+	)
+	defer inst.Free()
+
+	surf := surface.NewSurface(logger.With(slog.String("module", "surface")), inst, wm)
+	defer surf.Free()
+
+	return &CapabilityReport{
+		Instance: InstanceReport{
+			Extensions:       instance.AvailableExtensions(logger),
+			WindowExtensions: wm.GetRequiredInstanceExtensions(),
+		},
+		GPUs: physical.NewReport(
+			logger.With(slog.String("module", "physical")),
+			inst,
+			surf,
+			gpuSelector(cfg.GPU()),
+		),
+	}
+}
@@ -5,11 +5,14 @@ import (
 
 	"github.com/vulkan-go/vulkan"
 
+	"github.com/go-glx/vgl/internal/gpu/vlk/internal/pipeline"
 	"github.com/go-glx/vgl/internal/gpu/vlk/internal/shader"
 )
 
 const (
 	buildInShaderTriangle = "triangle"
+	buildInShaderRect     = "rect"
+	buildInShaderMesh     = "mesh"
 )
 
 var (
@@ -17,15 +20,105 @@ var (
 	triangleVert []byte
 	//go:embed shaders/triangle.frag.spv
 	triangleFrag []byte
+	//go:embed shaders/rect.vert.spv
+	rectVert []byte
+	//go:embed shaders/rect.frag.spv
+	rectFrag []byte
+	//go:embed shaders/mesh.vert.spv
+	meshVert []byte
 )
 
+// build-in shaders is embedded, so any reflection
+// error is a bug in compiled shader
+func defaultShader(id string, vert, frag []byte) *shader.Meta {
+	meta, err := shader.NewReflectedMeta(id, vert, frag, vulkan.PrimitiveTopologyTriangleList)
+	if err != nil {
+		panic(err)
+	}
+
+	return meta
+}
+
 func defaultShaderTriangle() *shader.Meta {
-	return shader.NewMeta(
-		buildInShaderTriangle,
-		triangleVert,
-		triangleFrag,
-		vulkan.PrimitiveTopologyTriangleList,
-		make([]vulkan.VertexInputBindingDescription, 0),
-		make([]vulkan.VertexInputAttributeDescription, 0),
-	)
+	return defaultShader(buildInShaderTriangle, triangleVert, triangleFrag)
+}
+
+func defaultShaderRect() *shader.Meta {
+	return defaultShader(buildInShaderRect, rectVert, rectFrag)
+}
+
+// mesh shader has same fragment stage as rect
+func defaultShaderMesh() *shader.Meta {
+	return defaultShader(buildInShaderMesh, meshVert, rectFrag).
+		WithSources(buildInShaderMesh, buildInShaderRect)
+}
+
+// shaderPipeline return graphics pipeline for shader, pipeline
+// is created by factory on first use and cached until rebuild
+func (vlk *VLK) shaderPipeline(sh *shader.Shader) vulkan.Pipeline {
+	return vlk.cont.pipelineFactory().Pipeline(sh.Meta().ID(), vlk.shaderPipelineOpts(sh)...)
+}
+
+func (vlk *VLK) shaderPipelineOpts(sh *shader.Shader) []pipeline.Initializer {
+	meta := sh.Meta()
+
+	// fragment shader convert colors into swapchain
+	// color space (sRGB for UNORM, PQ for HDR10, etc..)
+	outputTransform := uint32(vlk.cont.swapChain().Props().OutputTransform)
+
+	return []pipeline.Initializer{
+		pipeline.WithStages([]vulkan.PipelineShaderStageCreateInfo{
+			*sh.ModuleVert().Stage(),
+			sh.ModuleFrag().SpecializedStage([]uint32{outputTransform}),
+		}),
+		pipeline.WithLayout(vlk.cont.pipelineFactory().Layout(vlk.shaderLayout(meta))),
+		pipeline.WithTopology(meta.Topology()),
+		pipeline.WithVertexInput(
+			meta.Bindings(),
+			meta.Attributes(),
+		),
+		pipeline.WithRasterization(vulkan.PolygonModeFill),
+		pipeline.WithColorBlend(),
+		pipeline.WithMultisampling(),
+	}
+}
+
+// bindPipeline bind pipeline into frame commands,
+// if it not already bound in current frame
+func (vlk *VLK) bindPipeline(cb vulkan.CommandBuffer, pipe vulkan.Pipeline) {
+	if vlk.boundPipeline == pipe {
+		return
+	}
+
+	vulkan.CmdBindPipeline(cb, vulkan.PipelineBindPointGraphics, pipe)
+	vlk.boundPipeline = pipe
+	vlk.frameStats.PipelineBinds++
+}
+
+// shaderLayout describe shader resources for pipeline layout,
+// descriptor set layouts is taken from shader reflection
+func (vlk *VLK) shaderLayout(meta *shader.Meta) pipeline.LayoutDesc {
+	desc := pipeline.LayoutDesc{
+		PushConstants: meta.PushConstants(),
+	}
+
+	reflection := meta.Reflection()
+	if reflection == nil || len(reflection.DescriptorSets) == 0 {
+		return desc
+	}
+
+	// sets is sorted, not used sets between
+	// used ones get empty layout
+	lastSet := reflection.DescriptorSets[len(reflection.DescriptorSets)-1].Set
+	desc.SetLayouts = make([]vulkan.DescriptorSetLayout, lastSet+1)
+
+	for set := range desc.SetLayouts {
+		desc.SetLayouts[set] = vlk.cont.descriptorLayouts().Layout(nil)
+	}
+
+	for _, set := range reflection.DescriptorSets {
+		desc.SetLayouts[set.Set] = vlk.cont.descriptorLayouts().Layout(set.Bindings)
+	}
+
+	return desc
 }
@@ -1,2 +1,5 @@
 glslc triangle/fn.vert -o triangle.vert.spv
 glslc triangle/fn.frag -o triangle.frag.spv
+glslc rect/fn.vert -o rect.vert.spv
+glslc rect/fn.frag -o rect.frag.spv
+glslc mesh/fn.vert -o mesh.vert.spv
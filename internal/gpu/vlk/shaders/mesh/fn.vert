@@ -0,0 +1,17 @@
+#version 450
+
+// static mesh, uploaded once into device local memory
+// and drawn with per-draw model transform
+layout(push_constant) uniform Push {
+    mat4 transform;
+} push;
+
+layout(location = 0) in vec2 inPosition;
+layout(location = 1) in vec3 inColor;
+
+layout(location = 0) out vec3 outColor;
+
+void main() {
+    gl_Position = push.transform * vec4(inPosition, 0.0, 1.0);
+    outColor = inColor;
+}
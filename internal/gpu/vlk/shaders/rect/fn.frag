@@ -0,0 +1,50 @@
+#version 450
+
+// output transforms (see def.OutputTransform)
+const int outputNone = 0; // GPU encode colors by itself (sRGB formats), or linear output (scRGB)
+const int outputSRGB = 1; // UNORM surface, shader should encode sRGB
+const int outputPQ   = 2; // HDR10 surface, BT.2020 primaries with ST 2084 (PQ) encoding
+
+// SDR white level in HDR10 output (nits), as recommended in BT.2408
+const float paperWhite = 203.0;
+
+layout(constant_id = 0) const int outputTransform = outputNone;
+
+layout(location = 0) in vec3 fragColor;
+layout(location = 0) out vec4 outColor;
+
+vec3 toSRGB(vec3 linear) {
+    vec3 low = linear * 12.92;
+    vec3 high = 1.055 * pow(linear, vec3(1.0 / 2.4)) - 0.055;
+    return mix(high, low, lessThanEqual(linear, vec3(0.0031308)));
+}
+
+vec3 toPQ(vec3 linear) {
+    const mat3 bt709ToBT2020 = mat3(
+        0.6274, 0.0691, 0.0164,
+        0.3293, 0.9195, 0.0880,
+        0.0433, 0.0114, 0.8956
+    );
+
+    const float m1 = 0.1593017578125;
+    const float m2 = 78.84375;
+    const float c1 = 0.8359375;
+    const float c2 = 18.8515625;
+    const float c3 = 18.6875;
+
+    vec3 y = (bt709ToBT2020 * linear) * (paperWhite / 10000.0);
+    vec3 ym = pow(y, vec3(m1));
+    return pow((c1 + c2 * ym) / (1.0 + c3 * ym), vec3(m2));
+}
+
+void main() {
+    vec3 color = fragColor;
+
+    if (outputTransform == outputSRGB) {
+        color = toSRGB(color);
+    } else if (outputTransform == outputPQ) {
+        color = toPQ(color);
+    }
+
+    outColor = vec4(color, 1.0);
+}
@@ -0,0 +1,18 @@
+#version 450
+
+//layout(binding = 0) uniform UBO {
+//    mat4 proj;
+//    mat4 view;
+//    mat4 model;
+//} ubo;
+
+layout(location = 0) in vec2 inPosition;
+layout(location = 1) in vec3 inColor;
+
+layout(location = 0) out vec3 outColor;
+
+void main() {
+    //gl_Position = ubo.proj * ubo.view * ubo.model * vec4(inPosition, 0.0, 1.0);
+    gl_Position = vec4(inPosition, 0.0, 1.0);
+    outColor = inColor;
+}
@@ -1,20 +1,60 @@
 package vlk
 
-import "github.com/vulkan-go/vulkan"
+import (
+	"github.com/vulkan-go/vulkan"
 
-// todo: logger and log levels
-// todo: change config debug to log level = debug
+	"github.com/go-glx/vgl/driver"
+	"github.com/go-glx/vgl/internal/gpu/vlk/internal/must"
+	"github.com/go-glx/vgl/internal/gpu/vlk/internal/shader"
+)
 
 type VLK struct {
 	isReady bool
 	cont    *Container
+
+	rects         rectBatch       // not flushed rects in current frame
+	boundPipeline vulkan.Pipeline // latest bound pipeline in current frame
+
+	stats      driver.Stats // latest presented frame
+	frameStats driver.Stats // current frame (in progress)
+	skipped    uint64       // total skipped frames
+	rebuilds   uint64       // total swapchain rebuilds
+
+	// dev mode shaders watcher, nil when
+	// hot reload is disabled in config
+	shaderWatcher *shader.Watcher
+
+	// swapchain rebuild requested outside of vulkan (present
+	// mode changed, etc..), will be done on next frame start
+	rebuildRequested bool
 }
 
 func newVLK(cont *Container) *VLK {
-	return &VLK{
+	vlk := &VLK{
 		isReady: true,
 		cont:    cont,
 	}
+
+	if dir := cont.cfg.ShaderHotReloadDir(); dir != "" {
+		vlk.shaderWatcher = shader.NewWatcher(dir)
+	}
+
+	return vlk
+}
+
+// checkValidation pass all collected validation
+// errors to handler from config (if any)
+func (vlk *VLK) checkValidation() {
+	handler := vlk.cont.cfg.ValidationErrorHandler()
+	failures := vlk.cont.debugMessenger().Failures()
+
+	if handler == nil {
+		return
+	}
+
+	for _, err := range failures {
+		handler(err)
+	}
 }
 
 // this will immediately stop render new frames
@@ -26,7 +66,7 @@ func (vlk *VLK) maintenance(mutate func()) {
 	vlk.isReady = false
 
 	// wait for GPU end current operations
-	vulkan.DeviceWaitIdle(vlk.cont.logicalDevice().Ref())
+	must.Work(vulkan.DeviceWaitIdle(vlk.cont.logicalDevice().Ref()))
 
 	// change vulkan state
 	// rebuild pipeline, etc..
@@ -1,16 +1,26 @@
 package vlk
 
 import (
+	"fmt"
+	"image"
+
 	"github.com/vulkan-go/vulkan"
 
+	"github.com/go-glx/vgl/config"
+	"github.com/go-glx/vgl/driver"
 	"github.com/go-glx/vgl/glm"
-	"github.com/go-glx/vgl/internal/gpu/vlk/internal/pipeline"
+	"github.com/go-glx/vgl/internal/gpu/vlk/internal/must"
 )
 
 // WarmUp will warm vlk renderer and create all needed
 // objects for work, this must be called one time
 // before first FrameStart
 func (vlk *VLK) WarmUp() {
+	// messenger should be created before all other objects
+	// for catching all validation errors
+	_ = vlk.cont.debugMessenger()
+	defer vlk.checkValidation()
+
 	// request some managers, this will create it
 	// and all dependencies, like swapChain, renderPass, etc..
 	_ = vlk.cont.frameManager()
@@ -18,15 +28,43 @@ func (vlk *VLK) WarmUp() {
 }
 
 func (vlk *VLK) GPUWait() {
-	vulkan.DeviceWaitIdle(vlk.cont.logicalDevice().Ref())
+	must.Work(vulkan.DeviceWaitIdle(vlk.cont.logicalDevice().Ref()))
+	vlk.checkValidation()
 }
 
 func (vlk *VLK) FrameStart() {
 	if !vlk.isReady {
+		vlk.skipped++
+		return
+	}
+
+	if vlk.rebuildRequested {
+		vlk.rebuildRequested = false
+		vlk.cont.rebuild()
+	}
+
+	if vlk.shaderWatcher != nil {
+		vlk.reloadShaders()
+	}
+
+	vlk.frameStats = driver.Stats{}
+	vlk.boundPipeline = nil
+	vlk.rects.reset()
+
+	frames := vlk.cont.frameManager()
+	frames.FrameBegin()
+
+	if !frames.Available() {
+		vlk.skipped++
 		return
 	}
 
-	vlk.cont.frameManager().FrameBegin()
+	// GPU is done with this frame, so its buffers can be reused
+	// (ring buffers is destroyed before their frame memory is reset)
+	vlk.cont.frameRing().Begin(frames.FrameID())
+	vlk.cont.memoryAllocator().Begin(frames.FrameID())
+	vlk.cont.descriptorPools().Begin(frames.FrameID())
+	vlk.cont.meshes().begin()
 }
 
 func (vlk *VLK) FrameEnd() {
@@ -34,33 +72,10 @@ func (vlk *VLK) FrameEnd() {
 		return
 	}
 
-	// todo: remove test draw
-	triangle := vlk.cont.shaderManager().ShaderByID(buildInShaderTriangle)
-
-	pipe := vlk.cont.pipelineFactory().NewPipeline(
-		pipeline.WithStages([]vulkan.PipelineShaderStageCreateInfo{
-			*triangle.ModuleVert().Stage(),
-			*triangle.ModuleFrag().Stage(),
-		}),
-		pipeline.WithTopology(triangle.Meta().Topology()),
-		pipeline.WithVertexInput(
-			triangle.Meta().Bindings(),
-			triangle.Meta().Attributes(),
-		),
-		pipeline.WithRasterization(vulkan.PolygonModeFill),
-		pipeline.WithColorBlend(),
-		pipeline.WithMultisampling(),
-	)
-
-	vlk.cont.frameManager().FrameApplyCommands(func(_ uint32, cb vulkan.CommandBuffer) {
-		vulkan.CmdBindPipeline(cb, vulkan.PipelineBindPointGraphics, pipe)
-
-		// todo: 3,1 to shader
-		vulkan.CmdDraw(cb, 3, 1, 0, 0)
-	})
-	// todo: ^^^^^^^^^^^^^^
-
+	vlk.flushRects()
 	vlk.cont.frameManager().FrameEnd()
+	vlk.snapshotStats()
+	vlk.checkValidation()
 }
 
 func (vlk *VLK) DrawRect(vertexPos [4]glm.Vec2, vertexColor [4]glm.Vec3) {
@@ -68,9 +83,123 @@ func (vlk *VLK) DrawRect(vertexPos [4]glm.Vec2, vertexColor [4]glm.Vec3) {
 		return
 	}
 
-	// todo:
-	// vlk.cont.frameManager().FrameApplyCommands(func(cb vulkan.CommandBuffer) {
-	// 	// todo: add command to draw rect
-	// 	// todo: in current command buffer
-	// })
+	vlk.rects.add(vertexPos, vertexColor)
+}
+
+// RegisterShader compile custom shader modules, shader
+// pipeline will be created on first DrawCustom call
+func (vlk *VLK) RegisterShader(desc driver.ShaderDesc) error {
+	meta, err := vlk.customShaderMeta(desc)
+	if err != nil {
+		return err
+	}
+
+	return vlk.cont.shaderManager().RegisterShader(meta)
+}
+
+func (vlk *VLK) DrawCustom(shaderID string, vertices []byte, indices []uint32, uniforms []byte) {
+	if !vlk.isReady {
+		return
+	}
+
+	sh, err := vlk.cont.shaderManager().ShaderByID(shaderID)
+	if err != nil {
+		panic(err)
+	}
+
+	if err := checkCustomDraw(sh.Meta(), vertices, indices, uniforms); err != nil {
+		panic(fmt.Errorf("shader '%s': %w", shaderID, err))
+	}
+
+	// keep draw order with already queued rects
+	vlk.flushRects()
+	vlk.flushCustom(sh, vertices, indices, uniforms)
+}
+
+// NewMesh upload mesh into device local memory, it can
+// be called outside of frame (for example on level load)
+func (vlk *VLK) NewMesh(vertices []byte, indices []uint32, layout driver.VertexLayout) (driver.MeshID, error) {
+	return vlk.cont.meshes().create(vertices, indices, layout)
+}
+
+func (vlk *VLK) DrawMesh(mesh driver.MeshID, transform glm.Mat4) {
+	if !vlk.isReady {
+		return
+	}
+
+	// keep draw order with already queued rects
+	vlk.flushRects()
+	vlk.flushMesh(mesh, transform)
+}
+
+func (vlk *VLK) FreeMesh(mesh driver.MeshID) {
+	vlk.cont.meshes().release(mesh)
+}
+
+func (vlk *VLK) PushDebugGroup(name string) {
+	if !vlk.isReady {
+		return
+	}
+
+	vlk.flushRects()
+	vlk.cont.frameManager().PushLabel(name)
+}
+
+func (vlk *VLK) PopDebugGroup() {
+	if !vlk.isReady {
+		return
+	}
+
+	vlk.flushRects()
+	vlk.cont.frameManager().PopLabel()
+}
+
+// SetVSync change preferred present modes, swapchain
+// will be rebuilt with new mode on next frame start
+func (vlk *VLK) SetVSync(enabled bool) {
+	vlk.cont.presentModes = config.VSyncPresentModes(enabled)
+	vlk.rebuildRequested = true
+}
+
+// Screenshot return latest rendered frame, copied from GPU
+// memory. Available only when frame readback is enabled in config
+func (vlk *VLK) Screenshot() *image.RGBA {
+	return vlk.cont.frameManager().LastFrame()
+}
+
+// Stats return cached stats of latest frame (see snapshotStats).
+// It not touch container, so it's safe to call it anytime,
+// even during swapchain rebuild or after Close
+func (vlk *VLK) Stats() driver.Stats {
+	stats := vlk.stats
+	stats.SkippedFrames = vlk.skipped
+	stats.SwapchainRebuilds = vlk.rebuilds
+
+	return stats
+}
+
+// snapshotStats save current frame stats, should be
+// called in FrameEnd, when all objects is alive
+func (vlk *VLK) snapshotStats() {
+	stats := vlk.frameStats
+	stats.PresentMode = presentModeName(vlk.cont.swapChain().Props().PresentMode)
+
+	memStats := vlk.cont.memoryAllocator().Stats()
+	stats.MemoryBlocks = uint32(memStats.Blocks)
+	stats.MemoryAllocations = uint32(memStats.Allocations)
+	stats.MemoryReserved = memStats.Reserved
+	stats.MemoryUsed = memStats.Used
+
+	timings := vlk.cont.frameManager().GPUTimings()
+	stats.GPUFrameTime = timings.Frame
+	stats.GPUGroups = make([]driver.GPUTiming, 0, len(timings.Groups))
+
+	for _, group := range timings.Groups {
+		stats.GPUGroups = append(stats.GPUGroups, driver.GPUTiming{
+			Name:     group.Name,
+			Duration: group.Duration,
+		})
+	}
+
+	vlk.stats = stats
 }
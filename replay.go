@@ -0,0 +1,20 @@
+package vgl
+
+import (
+	"io"
+
+	"github.com/go-glx/vgl/internal/capture"
+)
+
+// Replay will render all frames from capture stream (see config.WithRecording)
+// with this renderer. Useful for reproducing rendering bugs
+// without game running
+func Replay(stream io.Reader, r *Render) (err error) {
+	if r.err != nil {
+		return r.err
+	}
+
+	defer recoverError(&err)
+
+	return capture.Replay(stream, r.api)
+}
@@ -0,0 +1,190 @@
+package vgl
+
+import (
+	"bytes"
+	"encoding/binary"
+	"errors"
+	"math"
+	"strings"
+	"testing"
+
+	"github.com/go-glx/vgl/config"
+	"github.com/go-glx/vgl/glm"
+)
+
+func TestReplay(t *testing.T) {
+	var recorded bytes.Buffer
+
+	original, err := NewRender(&goldenWM{}, config.NewConfig(
+		config.WithDriver(config.DriverSoftware),
+		config.WithRecording(&recorded),
+	))
+	if err != nil {
+		t.Fatalf("failed create render: %v", err)
+	}
+
+	for _, scene := range goldenScenes {
+		original.FrameStart()
+		original.PushDebugGroup(scene.name)
+		scene.draw(original)
+		original.PopDebugGroup()
+		original.FrameEnd()
+	}
+
+	if err = original.Close(); err != nil {
+		t.Fatalf("failed record capture: %v", err)
+	}
+
+	if lines := strings.Count(recorded.String(), "\n"); lines != len(goldenScenes) {
+		t.Fatalf("capture has %d frames, want %d", lines, len(goldenScenes))
+	}
+
+	// replay with recording again, capture should be the same
+	var replayed bytes.Buffer
+
+	target, err := NewRender(&goldenWM{}, config.NewConfig(
+		config.WithDriver(config.DriverSoftware),
+		config.WithRecording(&replayed),
+	))
+	if err != nil {
+		t.Fatalf("failed create render: %v", err)
+	}
+
+	if err = Replay(bytes.NewReader(recorded.Bytes()), target); err != nil {
+		t.Fatalf("failed replay: %v", err)
+	}
+
+	if err = target.Close(); err != nil {
+		t.Fatalf("failed record replay: %v", err)
+	}
+
+	if recorded.String() != replayed.String() {
+		t.Fatalf("replayed capture not match original:\n--- original\n%s\n--- replayed\n%s", recorded.String(), replayed.String())
+	}
+
+	// last replayed frame is the same as last recorded
+	if _, mismatched := diffImages(original.Screenshot(), target.Screenshot(), 0); mismatched > 0 {
+		t.Fatalf("replayed frame differ from original in %d pixels", mismatched)
+	}
+}
+
+func TestReplay_BrokenCapture(t *testing.T) {
+	target := newSoftwareRender(t)
+	defer target.Close()
+
+	tests := map[string]string{
+		"invalid json": "{\"frame\":1,",
+		"unknown op":   "{\"frame\":1,\"calls\":[{\"op\":\"teapot\"}]}\n",
+		"no data":      "{\"frame\":1,\"calls\":[{\"op\":\"rect\"}]}\n",
+		"no custom":    "{\"frame\":1,\"calls\":[{\"op\":\"custom\"}]}\n",
+	}
+
+	for name, stream := range tests {
+		t.Run(name, func(t *testing.T) {
+			if err := Replay(strings.NewReader(stream), target); err == nil {
+				t.Fatalf("expected replay error")
+			}
+		})
+	}
+}
+
+func TestReplay_CustomShader(t *testing.T) {
+	var recorded bytes.Buffer
+
+	render, err := NewRender(&goldenWM{}, config.NewConfig(
+		config.WithDriver(config.DriverSoftware),
+		config.WithRecording(&recorded),
+	))
+	if err != nil {
+		t.Fatalf("failed create render: %v", err)
+	}
+
+	render.FrameStart()
+	render.DrawCustom("sprite", []byte{1, 2, 3, 4}, []uint32{0, 1, 2}, []byte{5, 6, 7, 8})
+	render.FrameEnd()
+
+	if err = render.Close(); err != nil {
+		t.Fatalf("failed record capture: %v", err)
+	}
+
+	want := `{"frame":1,"calls":[{"op":"custom","custom":{"shader":"sprite","vertices":"AQIDBA==","indices":[0,1,2],"uniforms":"BQYHCA=="}}]}` + "\n"
+	if recorded.String() != want {
+		t.Fatalf("unexpected capture:\n%s\nwant:\n%s", recorded.String(), want)
+	}
+
+	target := newSoftwareRender(t)
+	defer target.Close()
+
+	if err = Replay(bytes.NewReader(recorded.Bytes()), target); err != nil {
+		t.Fatalf("failed replay: %v", err)
+	}
+}
+
+func TestReplay_Mesh(t *testing.T) {
+	var recorded bytes.Buffer
+
+	original, err := NewRender(&goldenWM{}, config.NewConfig(
+		config.WithDriver(config.DriverSoftware),
+		config.WithRecording(&recorded),
+	))
+	if err != nil {
+		t.Fatalf("failed create render: %v", err)
+	}
+
+	// triangle: vec2 position + vec3 color
+	vertices := make([]byte, 0, 3*20)
+	for _, vertex := range [3][5]float32{{-1, -1, 1, 0, 0}, {0, -1, 0, 1, 0}, {-1, 0, 0, 0, 1}} {
+		for _, value := range vertex {
+			vertices = binary.LittleEndian.AppendUint32(vertices, math.Float32bits(value))
+		}
+	}
+
+	layout := VertexLayout{
+		Stride: 20,
+		Attributes: []VertexAttribute{
+			{Location: MeshLocationPosition, Format: VertexFormatVec2, Offset: 0},
+			{Location: MeshLocationColor, Format: VertexFormatVec3, Offset: 8},
+		},
+	}
+
+	if _, err = original.NewMesh(vertices[:20], nil, layout); !errors.Is(err, ErrInvalidMesh) {
+		t.Fatalf("single vertex mesh: err = %v, want ErrInvalidMesh", err)
+	}
+
+	mesh, err := original.NewMesh(vertices, nil, layout)
+	if err != nil {
+		t.Fatalf("failed create mesh: %v", err)
+	}
+
+	moved := glm.Mat4Identity()
+	moved.D.R, moved.D.G = 1, 1
+
+	for frame := 0; frame < 2; frame++ {
+		original.FrameStart()
+		original.DrawMesh(mesh, glm.Mat4Identity())
+		original.DrawMesh(mesh, moved)
+		original.FrameEnd()
+	}
+
+	original.FreeMesh(mesh)
+
+	if err = original.Close(); err != nil {
+		t.Fatalf("failed record capture: %v", err)
+	}
+
+	// mesh data is captured once, on first draw
+	if count := strings.Count(recorded.String(), `"op":"new_mesh"`); count != 1 {
+		t.Fatalf("capture has %d mesh uploads, want 1:\n%s", count, recorded.String())
+	}
+
+	target := newSoftwareRender(t)
+	defer target.Close()
+
+	if err = Replay(bytes.NewReader(recorded.Bytes()), target); err != nil {
+		t.Fatalf("failed replay: %v", err)
+	}
+
+	if _, mismatched := diffImages(original.Screenshot(), target.Screenshot(), 0); mismatched > 0 {
+		t.Fatalf("replayed frame differ from original in %d pixels", mismatched)
+	}
+}
@@ -0,0 +1,130 @@
+package vgl
+
+import (
+	"sort"
+	"time"
+
+	"github.com/go-glx/vgl/driver"
+)
+
+// how many latest frames used for frame time percentiles
+const statsFramesWindow = 240
+
+type (
+	// Stats of latest presented frame, updated in every Render.FrameEnd
+	Stats struct {
+		FPS        float64       // presented frames per second (in latest second)
+		FrameTime  FrameTime     // time between frames, percentiles of latest frames
+		RecordTime time.Duration // CPU time spent from FrameStart to FrameEnd done
+		Frames     uint64        // total rendered frames
+
+		// driver counters: draw calls, vertices, batches,
+		// pipeline binds, skipped frames, swapchain rebuilds,
+		// GPU memory usage
+		driver.Stats
+	}
+
+	FrameTime struct {
+		P50 time.Duration
+		P95 time.Duration
+		P99 time.Duration
+		Max time.Duration
+	}
+
+	frameClock struct {
+		now func() time.Time
+
+		frameStart time.Time
+		lastEnd    time.Time
+
+		frames     uint64
+		recordTime time.Duration
+		durations  []time.Duration // ring buffer of latest frame times
+		next       int
+	}
+)
+
+func newFrameClock(now func() time.Time) *frameClock {
+	return &frameClock{
+		now:       now,
+		durations: make([]time.Duration, 0, statsFramesWindow),
+	}
+}
+
+func (c *frameClock) start() {
+	c.frameStart = c.now()
+}
+
+func (c *frameClock) end() {
+	end := c.now()
+	c.frames++
+	c.recordTime = end.Sub(c.frameStart)
+
+	if !c.lastEnd.IsZero() {
+		c.push(end.Sub(c.lastEnd))
+	}
+
+	c.lastEnd = end
+}
+
+func (c *frameClock) push(frameTime time.Duration) {
+	if len(c.durations) < statsFramesWindow {
+		c.durations = append(c.durations, frameTime)
+		return
+	}
+
+	c.durations[c.next] = frameTime
+	c.next = (c.next + 1) % statsFramesWindow
+}
+
+// latest return frame times from newest to oldest
+func (c *frameClock) latest() []time.Duration {
+	list := make([]time.Duration, 0, len(c.durations))
+
+	for i := 1; i <= len(c.durations); i++ {
+		ind := (c.next - i + len(c.durations)) % len(c.durations)
+		list = append(list, c.durations[ind])
+	}
+
+	return list
+}
+
+func (c *frameClock) fps() float64 {
+	frames := 0
+	total := time.Duration(0)
+
+	for _, frameTime := range c.latest() {
+		if total >= time.Second {
+			break
+		}
+
+		frames++
+		total += frameTime
+	}
+
+	if total <= 0 {
+		return 0
+	}
+
+	return float64(frames) / total.Seconds()
+}
+
+func (c *frameClock) frameTime() FrameTime {
+	if len(c.durations) == 0 {
+		return FrameTime{}
+	}
+
+	sorted := append([]time.Duration{}, c.durations...)
+	sort.Slice(sorted, func(i, j int) bool { return sorted[i] < sorted[j] })
+
+	percentile := func(p float64) time.Duration {
+		return sorted[int(p*float64(len(sorted)-1)+0.5)]
+	}
+
+	return FrameTime{
+		P50: percentile(0.50),
+		P95: percentile(0.95),
+		P99: percentile(0.99),
+		Max: sorted[len(sorted)-1],
+	}
+}
@@ -0,0 +1,105 @@
+package vgl
+
+import (
+	"testing"
+	"time"
+
+	"github.com/go-glx/vgl/config"
+	"github.com/go-glx/vgl/glm"
+)
+
+type fakeClock struct {
+	now time.Time
+}
+
+func (c *fakeClock) Now() time.Time {
+	return c.now
+}
+
+func (c *fakeClock) advance(d time.Duration) {
+	c.now = c.now.Add(d)
+}
+
+func TestFrameClock(t *testing.T) {
+	clock := &fakeClock{now: time.Unix(0, 0)}
+	fc := newFrameClock(clock.Now)
+
+	// 100 frames by 10ms (5ms record + 5ms idle),
+	// and one slow frame
+	for i := 0; i < 101; i++ {
+		record := 5 * time.Millisecond
+		if i == 100 {
+			record = 45 * time.Millisecond
+		}
+
+		fc.start()
+		clock.advance(record)
+		fc.end()
+		clock.advance(5 * time.Millisecond)
+	}
+
+	if fc.frames != 101 {
+		t.Fatalf("frames = %d, want 101", fc.frames)
+	}
+
+	if fc.recordTime != 45*time.Millisecond {
+		t.Fatalf("record time = %s, want 45ms", fc.recordTime)
+	}
+
+	ft := fc.frameTime()
+	if ft.P50 != 10*time.Millisecond || ft.P95 != 10*time.Millisecond || ft.Max != 50*time.Millisecond {
+		t.Fatalf("unexpected frame time percentiles: %+v", ft)
+	}
+
+	// latest second: 1 frame by 50ms + 95 frames by 10ms
+	if fps := fc.fps(); fps < 95.9 || fps > 96.1 {
+		t.Fatalf("fps = %.2f, want 96", fps)
+	}
+}
+
+func TestFrameClock_Window(t *testing.T) {
+	clock := &fakeClock{now: time.Unix(0, 0)}
+	fc := newFrameClock(clock.Now)
+
+	for i := 0; i < statsFramesWindow*2; i++ {
+		fc.start()
+		fc.end()
+		clock.advance(time.Millisecond * time.Duration(1+i/statsFramesWindow))
+	}
+
+	if len(fc.durations) != statsFramesWindow {
+		t.Fatalf("window size = %d, want %d", len(fc.durations), statsFramesWindow)
+	}
+
+	// only latest frames (2ms) in window
+	if ft := fc.frameTime(); ft.Max != 2*time.Millisecond || ft.P50 != 2*time.Millisecond {
+		t.Fatalf("old frames should leave window, got %+v", ft)
+	}
+}
+
+func TestRender_Stats(t *testing.T) {
+	renderer, err := NewRender(&goldenWM{}, config.NewConfig(
+		config.WithDriver(config.DriverSoftware),
+	))
+	if err != nil {
+		t.Fatalf("failed create render: %v", err)
+	}
+
+	defer renderer.Close()
+
+	renderer.FrameStart()
+	for i := 0; i < 3; i++ {
+		renderer.Draw2DRectExt(
+			[4]glm.Vec2{{X: -1, Y: -1}, {X: 0, Y: -1}, {X: 0, Y: 0}, {X: -1, Y: 0}},
+			[4]glm.Vec3{{R: 1}, {R: 1}, {R: 1}, {R: 1}},
+			false,
+		)
+	}
+	renderer.FrameEnd()
+
+	// rects is batched into one draw call, same as in vulkan
+	stats := renderer.Stats()
+	if stats.Frames != 1 || stats.DrawCalls != 1 || stats.Batches != 1 || stats.Vertices != 12 {
+		t.Fatalf("unexpected stats: %+v", stats)
+	}
+}